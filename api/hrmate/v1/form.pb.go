@@ -0,0 +1,864 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: hrmate/v1/form.proto
+
+package hrmatev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FormStatus int32
+
+const (
+	FormStatus_FORM_STATUS_UNSPECIFIED FormStatus = 0
+	FormStatus_FORM_STATUS_PENDING     FormStatus = 1
+	FormStatus_FORM_STATUS_APPROVED    FormStatus = 2
+	FormStatus_FORM_STATUS_REJECTED    FormStatus = 3
+)
+
+// Enum value maps for FormStatus.
+var (
+	FormStatus_name = map[int32]string{
+		0: "FORM_STATUS_UNSPECIFIED",
+		1: "FORM_STATUS_PENDING",
+		2: "FORM_STATUS_APPROVED",
+		3: "FORM_STATUS_REJECTED",
+	}
+	FormStatus_value = map[string]int32{
+		"FORM_STATUS_UNSPECIFIED": 0,
+		"FORM_STATUS_PENDING":     1,
+		"FORM_STATUS_APPROVED":    2,
+		"FORM_STATUS_REJECTED":    3,
+	}
+)
+
+func (x FormStatus) Enum() *FormStatus {
+	p := new(FormStatus)
+	*p = x
+	return p
+}
+
+func (x FormStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FormStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_hrmate_v1_form_proto_enumTypes[0].Descriptor()
+}
+
+func (FormStatus) Type() protoreflect.EnumType {
+	return &file_hrmate_v1_form_proto_enumTypes[0]
+}
+
+func (x FormStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FormStatus.Descriptor instead.
+func (FormStatus) EnumDescriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{0}
+}
+
+type Form struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title       string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	StartDate   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReviewedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	Status      FormStatus             `protobuf:"varint,9,opt,name=status,proto3,enum=hrmate.v1.FormStatus" json:"status,omitempty"`
+	Comment     *string                `protobuf:"bytes,10,opt,name=comment,proto3,oneof" json:"comment,omitempty"`
+	// version is incremented on every change, see expected_version
+	Version       int32 `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Form) Reset() {
+	*x = Form{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Form) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Form) ProtoMessage() {}
+
+func (x *Form) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Form.ProtoReflect.Descriptor instead.
+func (*Form) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Form) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Form) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Form) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Form) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Form) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Form) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Form) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Form) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *Form) GetStatus() FormStatus {
+	if x != nil {
+		return x.Status
+	}
+	return FormStatus_FORM_STATUS_UNSPECIFIED
+}
+
+func (x *Form) GetComment() string {
+	if x != nil && x.Comment != nil {
+		return *x.Comment
+	}
+	return ""
+}
+
+func (x *Form) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateFormRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFormRequest) Reset() {
+	*x = CreateFormRequest{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFormRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFormRequest) ProtoMessage() {}
+
+func (x *CreateFormRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFormRequest.ProtoReflect.Descriptor instead.
+func (*CreateFormRequest) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateFormRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateFormRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateFormRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *CreateFormRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type CreateFormResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Form          *Form                  `protobuf:"bytes,1,opt,name=form,proto3" json:"form,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFormResponse) Reset() {
+	*x = CreateFormResponse{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFormResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFormResponse) ProtoMessage() {}
+
+func (x *CreateFormResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFormResponse.ProtoReflect.Descriptor instead.
+func (*CreateFormResponse) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateFormResponse) GetForm() *Form {
+	if x != nil {
+		return x.Form
+	}
+	return nil
+}
+
+type GetFormRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFormRequest) Reset() {
+	*x = GetFormRequest{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFormRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFormRequest) ProtoMessage() {}
+
+func (x *GetFormRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFormRequest.ProtoReflect.Descriptor instead.
+func (*GetFormRequest) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetFormRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetFormResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Form          *Form                  `protobuf:"bytes,1,opt,name=form,proto3" json:"form,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFormResponse) Reset() {
+	*x = GetFormResponse{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFormResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFormResponse) ProtoMessage() {}
+
+func (x *GetFormResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFormResponse.ProtoReflect.Descriptor instead.
+func (*GetFormResponse) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetFormResponse) GetForm() *Form {
+	if x != nil {
+		return x.Form
+	}
+	return nil
+}
+
+type ListFormsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// user_id limits the result to the forms of one employee
+	UserId        string     `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        FormStatus `protobuf:"varint,2,opt,name=status,proto3,enum=hrmate.v1.FormStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFormsRequest) Reset() {
+	*x = ListFormsRequest{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFormsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFormsRequest) ProtoMessage() {}
+
+func (x *ListFormsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFormsRequest.ProtoReflect.Descriptor instead.
+func (*ListFormsRequest) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListFormsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFormsRequest) GetStatus() FormStatus {
+	if x != nil {
+		return x.Status
+	}
+	return FormStatus_FORM_STATUS_UNSPECIFIED
+}
+
+type ListFormsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Forms         []*Form                `protobuf:"bytes,1,rep,name=forms,proto3" json:"forms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFormsResponse) Reset() {
+	*x = ListFormsResponse{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFormsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFormsResponse) ProtoMessage() {}
+
+func (x *ListFormsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFormsResponse.ProtoReflect.Descriptor instead.
+func (*ListFormsResponse) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListFormsResponse) GetForms() []*Form {
+	if x != nil {
+		return x.Forms
+	}
+	return nil
+}
+
+type ApproveFormRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	// expected_version makes the call fail with ABORTED if the form has changed since
+	ExpectedVersion *int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ApproveFormRequest) Reset() {
+	*x = ApproveFormRequest{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveFormRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveFormRequest) ProtoMessage() {}
+
+func (x *ApproveFormRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveFormRequest.ProtoReflect.Descriptor instead.
+func (*ApproveFormRequest) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ApproveFormRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApproveFormRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ApproveFormRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ApproveFormResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Form          *Form                  `protobuf:"bytes,1,opt,name=form,proto3" json:"form,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveFormResponse) Reset() {
+	*x = ApproveFormResponse{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveFormResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveFormResponse) ProtoMessage() {}
+
+func (x *ApproveFormResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveFormResponse.ProtoReflect.Descriptor instead.
+func (*ApproveFormResponse) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ApproveFormResponse) GetForm() *Form {
+	if x != nil {
+		return x.Form
+	}
+	return nil
+}
+
+type RejectFormRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	// expected_version makes the call fail with ABORTED if the form has changed since
+	ExpectedVersion *int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RejectFormRequest) Reset() {
+	*x = RejectFormRequest{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectFormRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectFormRequest) ProtoMessage() {}
+
+func (x *RejectFormRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectFormRequest.ProtoReflect.Descriptor instead.
+func (*RejectFormRequest) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RejectFormRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RejectFormRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *RejectFormRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type RejectFormResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Form          *Form                  `protobuf:"bytes,1,opt,name=form,proto3" json:"form,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectFormResponse) Reset() {
+	*x = RejectFormResponse{}
+	mi := &file_hrmate_v1_form_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectFormResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectFormResponse) ProtoMessage() {}
+
+func (x *RejectFormResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hrmate_v1_form_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectFormResponse.ProtoReflect.Descriptor instead.
+func (*RejectFormResponse) Descriptor() ([]byte, []int) {
+	return file_hrmate_v1_form_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RejectFormResponse) GetForm() *Form {
+	if x != nil {
+		return x.Form
+	}
+	return nil
+}
+
+var File_hrmate_v1_form_proto protoreflect.FileDescriptor
+
+const file_hrmate_v1_form_proto_rawDesc = "" +
+	"\n" +
+	"\x14hrmate/v1/form.proto\x12\thrmate.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc5\x03\n" +
+	"\x04Form\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreviewed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x12-\n" +
+	"\x06status\x18\t \x01(\x0e2\x15.hrmate.v1.FormStatusR\x06status\x12\x1d\n" +
+	"\acomment\x18\n" +
+	" \x01(\tH\x00R\acomment\x88\x01\x01\x12\x18\n" +
+	"\aversion\x18\v \x01(\x05R\aversionB\n" +
+	"\n" +
+	"\b_comment\"\xbd\x01\n" +
+	"\x11CreateFormRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"9\n" +
+	"\x12CreateFormResponse\x12#\n" +
+	"\x04form\x18\x01 \x01(\v2\x0f.hrmate.v1.FormR\x04form\" \n" +
+	"\x0eGetFormRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"6\n" +
+	"\x0fGetFormResponse\x12#\n" +
+	"\x04form\x18\x01 \x01(\v2\x0f.hrmate.v1.FormR\x04form\"Z\n" +
+	"\x10ListFormsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.hrmate.v1.FormStatusR\x06status\":\n" +
+	"\x11ListFormsResponse\x12%\n" +
+	"\x05forms\x18\x01 \x03(\v2\x0f.hrmate.v1.FormR\x05forms\"\x83\x01\n" +
+	"\x12ApproveFormRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\":\n" +
+	"\x13ApproveFormResponse\x12#\n" +
+	"\x04form\x18\x01 \x01(\v2\x0f.hrmate.v1.FormR\x04form\"\x82\x01\n" +
+	"\x11RejectFormRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"9\n" +
+	"\x12RejectFormResponse\x12#\n" +
+	"\x04form\x18\x01 \x01(\v2\x0f.hrmate.v1.FormR\x04form*v\n" +
+	"\n" +
+	"FormStatus\x12\x1b\n" +
+	"\x17FORM_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13FORM_STATUS_PENDING\x10\x01\x12\x18\n" +
+	"\x14FORM_STATUS_APPROVED\x10\x02\x12\x18\n" +
+	"\x14FORM_STATUS_REJECTED\x10\x032\xfb\x02\n" +
+	"\vFormService\x12I\n" +
+	"\n" +
+	"CreateForm\x12\x1c.hrmate.v1.CreateFormRequest\x1a\x1d.hrmate.v1.CreateFormResponse\x12@\n" +
+	"\aGetForm\x12\x19.hrmate.v1.GetFormRequest\x1a\x1a.hrmate.v1.GetFormResponse\x12F\n" +
+	"\tListForms\x12\x1b.hrmate.v1.ListFormsRequest\x1a\x1c.hrmate.v1.ListFormsResponse\x12L\n" +
+	"\vApproveForm\x12\x1d.hrmate.v1.ApproveFormRequest\x1a\x1e.hrmate.v1.ApproveFormResponse\x12I\n" +
+	"\n" +
+	"RejectForm\x12\x1c.hrmate.v1.RejectFormRequest\x1a\x1d.hrmate.v1.RejectFormResponseB3Z1github.com/platonso/hrmate/api/hrmate/v1;hrmatev1b\x06proto3"
+
+var (
+	file_hrmate_v1_form_proto_rawDescOnce sync.Once
+	file_hrmate_v1_form_proto_rawDescData []byte
+)
+
+func file_hrmate_v1_form_proto_rawDescGZIP() []byte {
+	file_hrmate_v1_form_proto_rawDescOnce.Do(func() {
+		file_hrmate_v1_form_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hrmate_v1_form_proto_rawDesc), len(file_hrmate_v1_form_proto_rawDesc)))
+	})
+	return file_hrmate_v1_form_proto_rawDescData
+}
+
+var file_hrmate_v1_form_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_hrmate_v1_form_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_hrmate_v1_form_proto_goTypes = []any{
+	(FormStatus)(0),               // 0: hrmate.v1.FormStatus
+	(*Form)(nil),                  // 1: hrmate.v1.Form
+	(*CreateFormRequest)(nil),     // 2: hrmate.v1.CreateFormRequest
+	(*CreateFormResponse)(nil),    // 3: hrmate.v1.CreateFormResponse
+	(*GetFormRequest)(nil),        // 4: hrmate.v1.GetFormRequest
+	(*GetFormResponse)(nil),       // 5: hrmate.v1.GetFormResponse
+	(*ListFormsRequest)(nil),      // 6: hrmate.v1.ListFormsRequest
+	(*ListFormsResponse)(nil),     // 7: hrmate.v1.ListFormsResponse
+	(*ApproveFormRequest)(nil),    // 8: hrmate.v1.ApproveFormRequest
+	(*ApproveFormResponse)(nil),   // 9: hrmate.v1.ApproveFormResponse
+	(*RejectFormRequest)(nil),     // 10: hrmate.v1.RejectFormRequest
+	(*RejectFormResponse)(nil),    // 11: hrmate.v1.RejectFormResponse
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_hrmate_v1_form_proto_depIdxs = []int32{
+	12, // 0: hrmate.v1.Form.start_date:type_name -> google.protobuf.Timestamp
+	12, // 1: hrmate.v1.Form.end_date:type_name -> google.protobuf.Timestamp
+	12, // 2: hrmate.v1.Form.created_at:type_name -> google.protobuf.Timestamp
+	12, // 3: hrmate.v1.Form.reviewed_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: hrmate.v1.Form.status:type_name -> hrmate.v1.FormStatus
+	12, // 5: hrmate.v1.CreateFormRequest.start_date:type_name -> google.protobuf.Timestamp
+	12, // 6: hrmate.v1.CreateFormRequest.end_date:type_name -> google.protobuf.Timestamp
+	1,  // 7: hrmate.v1.CreateFormResponse.form:type_name -> hrmate.v1.Form
+	1,  // 8: hrmate.v1.GetFormResponse.form:type_name -> hrmate.v1.Form
+	0,  // 9: hrmate.v1.ListFormsRequest.status:type_name -> hrmate.v1.FormStatus
+	1,  // 10: hrmate.v1.ListFormsResponse.forms:type_name -> hrmate.v1.Form
+	1,  // 11: hrmate.v1.ApproveFormResponse.form:type_name -> hrmate.v1.Form
+	1,  // 12: hrmate.v1.RejectFormResponse.form:type_name -> hrmate.v1.Form
+	2,  // 13: hrmate.v1.FormService.CreateForm:input_type -> hrmate.v1.CreateFormRequest
+	4,  // 14: hrmate.v1.FormService.GetForm:input_type -> hrmate.v1.GetFormRequest
+	6,  // 15: hrmate.v1.FormService.ListForms:input_type -> hrmate.v1.ListFormsRequest
+	8,  // 16: hrmate.v1.FormService.ApproveForm:input_type -> hrmate.v1.ApproveFormRequest
+	10, // 17: hrmate.v1.FormService.RejectForm:input_type -> hrmate.v1.RejectFormRequest
+	3,  // 18: hrmate.v1.FormService.CreateForm:output_type -> hrmate.v1.CreateFormResponse
+	5,  // 19: hrmate.v1.FormService.GetForm:output_type -> hrmate.v1.GetFormResponse
+	7,  // 20: hrmate.v1.FormService.ListForms:output_type -> hrmate.v1.ListFormsResponse
+	9,  // 21: hrmate.v1.FormService.ApproveForm:output_type -> hrmate.v1.ApproveFormResponse
+	11, // 22: hrmate.v1.FormService.RejectForm:output_type -> hrmate.v1.RejectFormResponse
+	18, // [18:23] is the sub-list for method output_type
+	13, // [13:18] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_hrmate_v1_form_proto_init() }
+func file_hrmate_v1_form_proto_init() {
+	if File_hrmate_v1_form_proto != nil {
+		return
+	}
+	file_hrmate_v1_form_proto_msgTypes[0].OneofWrappers = []any{}
+	file_hrmate_v1_form_proto_msgTypes[7].OneofWrappers = []any{}
+	file_hrmate_v1_form_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hrmate_v1_form_proto_rawDesc), len(file_hrmate_v1_form_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hrmate_v1_form_proto_goTypes,
+		DependencyIndexes: file_hrmate_v1_form_proto_depIdxs,
+		EnumInfos:         file_hrmate_v1_form_proto_enumTypes,
+		MessageInfos:      file_hrmate_v1_form_proto_msgTypes,
+	}.Build()
+	File_hrmate_v1_form_proto = out.File
+	file_hrmate_v1_form_proto_goTypes = nil
+	file_hrmate_v1_form_proto_depIdxs = nil
+}
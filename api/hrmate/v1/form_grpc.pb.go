@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: hrmate/v1/form.proto
+
+package hrmatev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FormService_CreateForm_FullMethodName  = "/hrmate.v1.FormService/CreateForm"
+	FormService_GetForm_FullMethodName     = "/hrmate.v1.FormService/GetForm"
+	FormService_ListForms_FullMethodName   = "/hrmate.v1.FormService/ListForms"
+	FormService_ApproveForm_FullMethodName = "/hrmate.v1.FormService/ApproveForm"
+	FormService_RejectForm_FullMethodName  = "/hrmate.v1.FormService/RejectForm"
+)
+
+// FormServiceClient is the client API for FormService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FormService submits and reviews employee requests. Employees create and read
+// their own forms, HR reads and reviews the forms assigned to them.
+type FormServiceClient interface {
+	CreateForm(ctx context.Context, in *CreateFormRequest, opts ...grpc.CallOption) (*CreateFormResponse, error)
+	GetForm(ctx context.Context, in *GetFormRequest, opts ...grpc.CallOption) (*GetFormResponse, error)
+	ListForms(ctx context.Context, in *ListFormsRequest, opts ...grpc.CallOption) (*ListFormsResponse, error)
+	ApproveForm(ctx context.Context, in *ApproveFormRequest, opts ...grpc.CallOption) (*ApproveFormResponse, error)
+	RejectForm(ctx context.Context, in *RejectFormRequest, opts ...grpc.CallOption) (*RejectFormResponse, error)
+}
+
+type formServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFormServiceClient(cc grpc.ClientConnInterface) FormServiceClient {
+	return &formServiceClient{cc}
+}
+
+func (c *formServiceClient) CreateForm(ctx context.Context, in *CreateFormRequest, opts ...grpc.CallOption) (*CreateFormResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFormResponse)
+	err := c.cc.Invoke(ctx, FormService_CreateForm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *formServiceClient) GetForm(ctx context.Context, in *GetFormRequest, opts ...grpc.CallOption) (*GetFormResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFormResponse)
+	err := c.cc.Invoke(ctx, FormService_GetForm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *formServiceClient) ListForms(ctx context.Context, in *ListFormsRequest, opts ...grpc.CallOption) (*ListFormsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFormsResponse)
+	err := c.cc.Invoke(ctx, FormService_ListForms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *formServiceClient) ApproveForm(ctx context.Context, in *ApproveFormRequest, opts ...grpc.CallOption) (*ApproveFormResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveFormResponse)
+	err := c.cc.Invoke(ctx, FormService_ApproveForm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *formServiceClient) RejectForm(ctx context.Context, in *RejectFormRequest, opts ...grpc.CallOption) (*RejectFormResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectFormResponse)
+	err := c.cc.Invoke(ctx, FormService_RejectForm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FormServiceServer is the server API for FormService service.
+// All implementations must embed UnimplementedFormServiceServer
+// for forward compatibility.
+//
+// FormService submits and reviews employee requests. Employees create and read
+// their own forms, HR reads and reviews the forms assigned to them.
+type FormServiceServer interface {
+	CreateForm(context.Context, *CreateFormRequest) (*CreateFormResponse, error)
+	GetForm(context.Context, *GetFormRequest) (*GetFormResponse, error)
+	ListForms(context.Context, *ListFormsRequest) (*ListFormsResponse, error)
+	ApproveForm(context.Context, *ApproveFormRequest) (*ApproveFormResponse, error)
+	RejectForm(context.Context, *RejectFormRequest) (*RejectFormResponse, error)
+	mustEmbedUnimplementedFormServiceServer()
+}
+
+// UnimplementedFormServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFormServiceServer struct{}
+
+func (UnimplementedFormServiceServer) CreateForm(context.Context, *CreateFormRequest) (*CreateFormResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateForm not implemented")
+}
+func (UnimplementedFormServiceServer) GetForm(context.Context, *GetFormRequest) (*GetFormResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetForm not implemented")
+}
+func (UnimplementedFormServiceServer) ListForms(context.Context, *ListFormsRequest) (*ListFormsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListForms not implemented")
+}
+func (UnimplementedFormServiceServer) ApproveForm(context.Context, *ApproveFormRequest) (*ApproveFormResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveForm not implemented")
+}
+func (UnimplementedFormServiceServer) RejectForm(context.Context, *RejectFormRequest) (*RejectFormResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectForm not implemented")
+}
+func (UnimplementedFormServiceServer) mustEmbedUnimplementedFormServiceServer() {}
+func (UnimplementedFormServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeFormServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FormServiceServer will
+// result in compilation errors.
+type UnsafeFormServiceServer interface {
+	mustEmbedUnimplementedFormServiceServer()
+}
+
+func RegisterFormServiceServer(s grpc.ServiceRegistrar, srv FormServiceServer) {
+	// If the following call panics, it indicates UnimplementedFormServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FormService_ServiceDesc, srv)
+}
+
+func _FormService_CreateForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFormRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FormServiceServer).CreateForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FormService_CreateForm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FormServiceServer).CreateForm(ctx, req.(*CreateFormRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FormService_GetForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFormRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FormServiceServer).GetForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FormService_GetForm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FormServiceServer).GetForm(ctx, req.(*GetFormRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FormService_ListForms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFormsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FormServiceServer).ListForms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FormService_ListForms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FormServiceServer).ListForms(ctx, req.(*ListFormsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FormService_ApproveForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveFormRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FormServiceServer).ApproveForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FormService_ApproveForm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FormServiceServer).ApproveForm(ctx, req.(*ApproveFormRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FormService_RejectForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectFormRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FormServiceServer).RejectForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FormService_RejectForm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FormServiceServer).RejectForm(ctx, req.(*RejectFormRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FormService_ServiceDesc is the grpc.ServiceDesc for FormService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FormService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hrmate.v1.FormService",
+	HandlerType: (*FormServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateForm",
+			Handler:    _FormService_CreateForm_Handler,
+		},
+		{
+			MethodName: "GetForm",
+			Handler:    _FormService_GetForm_Handler,
+		},
+		{
+			MethodName: "ListForms",
+			Handler:    _FormService_ListForms_Handler,
+		},
+		{
+			MethodName: "ApproveForm",
+			Handler:    _FormService_ApproveForm_Handler,
+		},
+		{
+			MethodName: "RejectForm",
+			Handler:    _FormService_RejectForm_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hrmate/v1/form.proto",
+}
@@ -3,15 +3,16 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/platonso/hrmate/internal/app"
 	"github.com/platonso/hrmate/internal/config"
+	"github.com/platonso/hrmate/internal/logger"
 )
 
 func main() {
@@ -21,12 +22,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	appLogger, err := logger.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		log.Printf("Logger error: %v", err)
+		os.Exit(1)
+	}
+	// Libraries and code without a request context log through the default logger
+	slog.SetDefault(appLogger)
+
 	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	a, err := app.New(signalCtx, cfg)
+	a, err := app.New(signalCtx, cfg, appLogger)
 	if err != nil {
-		log.Printf("Failed to init app: %v", err)
+		appLogger.Error("failed to init app", "error", err)
 		os.Exit(1)
 	}
 
@@ -35,17 +44,18 @@ func main() {
 
 	select {
 	case err := <-errChan:
-		log.Printf("Server stopped with error: %v", err)
+		appLogger.Error("server stopped with error", "error", err)
 		os.Exit(1)
 
 	case <-signalCtx.Done():
-		log.Println("Shutdown signal received")
+		appLogger.Info("shutdown signal received")
 
-		shutdownCtx, cancel := context.WithTimeout(signalCtx, 10*time.Second)
+		// signalCtx is already cancelled at this point
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 		defer cancel()
 
 		if err := a.Stop(shutdownCtx); err != nil {
-			log.Printf("Graceful shutdown failed: %v", err)
+			appLogger.Error("graceful shutdown failed", "error", err)
 			os.Exit(1)
 		}
 	}
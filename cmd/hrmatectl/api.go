@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+)
+
+const apiPrefix = "/api/v1"
+
+// apiClient reads through the GraphQL endpoint, which applies the same visibility
+// rules for every role, and changes data through the REST endpoints.
+type apiClient struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+func newAPIClient(server, token string) *apiClient {
+	return &apiClient{
+		server: server,
+		token:  token,
+		http:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// apiError is an error response of the API, with the same codes as the errors package.
+type apiError struct {
+	Code       string
+	Message    string
+	StatusCode int
+}
+
+func (e *apiError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: %s (HTTP %d)", e.Code, e.Message, e.StatusCode)
+}
+
+func (c *apiClient) login(ctx context.Context, email, password string) error {
+	var resp struct {
+		Token string `json:"token"`
+	}
+	body := map[string]string{"email": email, "password": password}
+	if err := c.do(ctx, http.MethodPost, "/login", body, nil, &resp); err != nil {
+		return err
+	}
+
+	c.token = resp.Token
+	return nil
+}
+
+func (c *apiClient) me(ctx context.Context) (*userView, error) {
+	var data struct {
+		Me gqlUser `json:"me"`
+	}
+	if err := c.query(ctx, `query { me { `+userFields+` } }`, nil, &data); err != nil {
+		return nil, err
+	}
+
+	user := data.Me.view()
+	return &user, nil
+}
+
+func (c *apiClient) ListUsers(ctx context.Context, filter userFilter) ([]userView, error) {
+	vars := map[string]any{}
+	if filter.Role != nil {
+		vars["role"] = strings.ToUpper(string(*filter.Role))
+	}
+	if filter.IsActive != nil {
+		vars["isActive"] = *filter.IsActive
+	}
+	if filter.Department != nil {
+		vars["department"] = *filter.Department
+	}
+
+	query := `query($role: Role, $isActive: Boolean, $department: String, $after: String) {
+		users(role: $role, isActive: $isActive, department: $department, first: 500, after: $after) {
+			nodes { ` + userFields + ` }
+			endCursor
+		}
+	}`
+
+	// The CLI lists everything, so the pages are fetched one after another. A page
+	// of 500 stays within the default complexity limit of the GraphQL endpoint.
+	users := []userView{}
+	for {
+		var data struct {
+			Users struct {
+				Nodes     []gqlUser `json:"nodes"`
+				EndCursor *string   `json:"endCursor"`
+			} `json:"users"`
+		}
+		if err := c.query(ctx, query, vars, &data); err != nil {
+			return nil, err
+		}
+
+		for _, u := range data.Users.Nodes {
+			users = append(users, u.view())
+		}
+
+		if data.Users.EndCursor == nil {
+			return users, nil
+		}
+		vars["after"] = *data.Users.EndCursor
+	}
+}
+
+func (c *apiClient) GetUser(ctx context.Context, userID uuid.UUID) (*userView, error) {
+	var data struct {
+		User *gqlUser `json:"user"`
+	}
+	query := `query($id: ID!) { user(id: $id) { ` + userFields + ` } }`
+	if err := c.query(ctx, query, map[string]any{"id": userID}, &data); err != nil {
+		return nil, err
+	}
+	if data.User == nil {
+		return nil, &apiError{Code: errs.ErrUserNotFound.Error(), Message: "user not found"}
+	}
+
+	user := data.User.view()
+	return &user, nil
+}
+
+func (c *apiClient) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool, expectedVersion *int) error {
+	action := "deactivate"
+	if isActive {
+		action = "activate"
+	}
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/admin/users/%s/%s", userID, action), nil, expectedVersion, nil)
+}
+
+func (c *apiClient) ListForms(ctx context.Context, filter *formservice.Filter) ([]formView, error) {
+	vars := map[string]any{}
+	if filter.UserID != nil {
+		vars["userId"] = *filter.UserID
+	}
+	if filter.ExecutorID != nil {
+		vars["executorId"] = *filter.ExecutorID
+	}
+	if filter.FormStatus != nil {
+		vars["status"] = strings.ToUpper(string(*filter.FormStatus))
+	}
+
+	var data struct {
+		Forms []gqlForm `json:"forms"`
+	}
+	query := `query($userId: ID, $executorId: ID, $status: FormStatus) {
+		forms(userId: $userId, executorId: $executorId, status: $status) { ` + formFields + ` }
+	}`
+	if err := c.query(ctx, query, vars, &data); err != nil {
+		return nil, err
+	}
+
+	forms := make([]formView, len(data.Forms))
+	for i, f := range data.Forms {
+		forms[i] = f.view()
+	}
+	return forms, nil
+}
+
+func (c *apiClient) GetForm(ctx context.Context, formID uuid.UUID) (*formView, error) {
+	var data struct {
+		Form *gqlForm `json:"form"`
+	}
+	query := `query($id: ID!) { form(id: $id) { ` + formFields + ` } }`
+	if err := c.query(ctx, query, map[string]any{"id": formID}, &data); err != nil {
+		return nil, err
+	}
+	if data.Form == nil {
+		return nil, &apiError{Code: errs.ErrFormNotFound.Error(), Message: "form not found"}
+	}
+
+	form := data.Form.view()
+	return &form, nil
+}
+
+func (c *apiClient) ReviewForm(ctx context.Context, formID uuid.UUID, approve bool, comment string, expectedVersion *int) error {
+	action := "reject"
+	if approve {
+		action = "approve"
+	}
+
+	body := map[string]string{}
+	if comment != "" {
+		body["comment"] = comment
+	}
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/hr/forms/%s/%s", formID, action), body, expectedVersion, nil)
+}
+
+func (c *apiClient) ResetPassword(context.Context, string, string) error {
+	return errors.New("admin reset-password works on the database only, run it with -offline")
+}
+
+const userFields = `id role firstName lastName position email isActive department version`
+
+const formFields = `id title description startDate endDate createdAt reviewedAt status comment version
+	author { email } executor { email }`
+
+type gqlUser struct {
+	ID         uuid.UUID `json:"id"`
+	Role       string    `json:"role"`
+	FirstName  string    `json:"firstName"`
+	LastName   string    `json:"lastName"`
+	Position   string    `json:"position"`
+	Email      string    `json:"email"`
+	IsActive   bool      `json:"isActive"`
+	Department *string   `json:"department"`
+	Version    int       `json:"version"`
+}
+
+func (u gqlUser) view() userView {
+	view := userView{
+		ID:        u.ID,
+		Role:      domain.Role(strings.ToLower(u.Role)),
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Position:  u.Position,
+		Email:     u.Email,
+		IsActive:  u.IsActive,
+		Version:   u.Version,
+	}
+	if u.Department != nil {
+		view.Department = *u.Department
+	}
+	return view
+}
+
+type gqlForm struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	StartDate   *time.Time `json:"startDate"`
+	EndDate     *time.Time `json:"endDate"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ReviewedAt  *time.Time `json:"reviewedAt"`
+	Status      string     `json:"status"`
+	Comment     *string    `json:"comment"`
+	Version     int        `json:"version"`
+	Author      *struct {
+		Email string `json:"email"`
+	} `json:"author"`
+	Executor *struct {
+		Email string `json:"email"`
+	} `json:"executor"`
+}
+
+func (f gqlForm) view() formView {
+	view := formView{
+		ID:          f.ID,
+		Title:       f.Title,
+		Description: f.Description,
+		StartDate:   f.StartDate,
+		EndDate:     f.EndDate,
+		CreatedAt:   f.CreatedAt,
+		ReviewedAt:  f.ReviewedAt,
+		Status:      domain.FormStatus(strings.ToLower(f.Status)),
+		Comment:     f.Comment,
+		Version:     f.Version,
+	}
+	if f.Author != nil {
+		view.Author = f.Author.Email
+	}
+	if f.Executor != nil {
+		view.Executor = f.Executor.Email
+	}
+	return view
+}
+
+// query runs a GraphQL query and reports its first error, as a query either
+// succeeds or fails as a whole for the commands.
+func (c *apiClient) query(ctx context.Context, query string, vars map[string]any, data any) error {
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	body := map[string]any{"query": query, "variables": vars}
+	if err := c.do(ctx, http.MethodPost, "/graphql", body, nil, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		code := resp.Errors[0].Extensions.Code
+		if code == "" {
+			code = errs.ErrInvalidRequest.Error()
+		}
+		return &apiError{Code: code, Message: resp.Errors[0].Message}
+	}
+
+	if err := json.Unmarshal(resp.Data, data); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string, body any, expectedVersion *int, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.server+apiPrefix+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if expectedVersion != nil {
+		req.Header.Set("If-Match", strconv.Quote(strconv.Itoa(*expectedVersion)))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", c.server, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func decodeError(resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Details []struct {
+				Field   string `json:"field"`
+				Message string `json:"message"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error.Code == "" {
+		return &apiError{Code: errs.ErrInternalServer.Error(), Message: resp.Status, StatusCode: resp.StatusCode}
+	}
+
+	message := body.Error.Message
+	for _, detail := range body.Error.Details {
+		message += fmt.Sprintf("; %s: %s", detail.Field, detail.Message)
+	}
+	return &apiError{Code: body.Error.Code, Message: message, StatusCode: resp.StatusCode}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+)
+
+// backend is implemented by the API client and by the offline database access,
+// so every command behaves the same in both modes.
+type backend interface {
+	ListUsers(ctx context.Context, filter userFilter) ([]userView, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*userView, error)
+	SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool, expectedVersion *int) error
+	ListForms(ctx context.Context, filter *formservice.Filter) ([]formView, error)
+	GetForm(ctx context.Context, formID uuid.UUID) (*formView, error)
+	ReviewForm(ctx context.Context, formID uuid.UUID, approve bool, comment string, expectedVersion *int) error
+	ResetPassword(ctx context.Context, email, password string) error
+}
+
+type userFilter struct {
+	Role       *domain.Role
+	IsActive   *bool
+	Department *string
+}
+
+func (f userFilter) matches(user userView) bool {
+	switch {
+	case f.Role != nil && user.Role != *f.Role:
+		return false
+	case f.IsActive != nil && user.IsActive != *f.IsActive:
+		return false
+	case f.Department != nil && user.Department != *f.Department:
+		return false
+	}
+	return true
+}
+
+type userView struct {
+	ID         uuid.UUID   `json:"id"`
+	Role       domain.Role `json:"role"`
+	FirstName  string      `json:"firstName"`
+	LastName   string      `json:"lastName"`
+	Position   string      `json:"position"`
+	Email      string      `json:"email"`
+	IsActive   bool        `json:"isActive"`
+	Department string      `json:"department,omitempty"`
+	Version    int         `json:"version"`
+}
+
+// formView names the author and executor by email, which is what operators search for.
+type formView struct {
+	ID          uuid.UUID         `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	StartDate   *time.Time        `json:"startDate"`
+	EndDate     *time.Time        `json:"endDate"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	ReviewedAt  *time.Time        `json:"reviewedAt"`
+	Status      domain.FormStatus `json:"status"`
+	Comment     *string           `json:"comment"`
+	Author      string            `json:"author,omitempty"`
+	Executor    string            `json:"executor,omitempty"`
+	Version     int               `json:"version"`
+}
+
+func toUserView(user *domain.User) userView {
+	return userView{
+		ID:         user.ID,
+		Role:       user.Role,
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		Position:   user.Position,
+		Email:      user.Email,
+		IsActive:   user.IsActive,
+		Department: user.Department,
+		Version:    user.Version,
+	}
+}
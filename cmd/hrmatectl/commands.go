@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	"golang.org/x/term"
+)
+
+func login(ctx context.Context, opts options, args []string, out io.Writer) error {
+	if opts.offline {
+		return errors.New("login is not needed with -offline")
+	}
+
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	email := fs.String("email", "", "email of the account, defaults to the last one used")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	token := fs.String("token", "", "use an API token (hrm_...) instead of email and password")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	creds, err := resolveCredentials(opts.server)
+	if err != nil {
+		return err
+	}
+
+	client := newAPIClient(creds.Server, *token)
+	if *token == "" {
+		if *email == "" {
+			*email = creds.Email
+		}
+		if *email == "" {
+			return errors.New("-email is required")
+		}
+
+		password, err := readPassword("Password: ", *passwordStdin)
+		if err != nil {
+			return err
+		}
+
+		if err := client.login(ctx, *email, password); err != nil {
+			return err
+		}
+	}
+
+	me, err := client.me(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := saveCredentials(&credentials{Server: creds.Server, Token: client.token, Email: me.Email}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Logged in to %s as %s (%s)\n", creds.Server, me.Email, me.Role)
+	return nil
+}
+
+func logout(out io.Writer) error {
+	if err := removeCredentials(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Logged out")
+	return nil
+}
+
+func runUsers(ctx context.Context, b backend, p *printer, args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing users subcommand: list, show, activate or deactivate")
+	}
+	subcommand, args := args[0], args[1:]
+
+	switch subcommand {
+	case "list":
+		fs := flag.NewFlagSet("users list", flag.ContinueOnError)
+		role := fs.String("role", "", "only users with the role (employee, hr, admin)")
+		active := fs.String("active", "", "only active (true) or inactive (false) users")
+		department := fs.String("department", "", "only users of the department")
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+
+		var filter userFilter
+		if *role != "" {
+			r := domain.Role(strings.ToLower(*role))
+			if r != domain.RoleEmployee && r != domain.RoleHR && r != domain.RoleAdmin {
+				return fmt.Errorf("invalid role %q, expected employee, hr or admin", *role)
+			}
+			filter.Role = &r
+		}
+		if *active != "" {
+			isActive, err := strconv.ParseBool(*active)
+			if err != nil {
+				return fmt.Errorf("invalid -active value %q, expected true or false", *active)
+			}
+			filter.IsActive = &isActive
+		}
+		if *department != "" {
+			filter.Department = department
+		}
+
+		users, err := b.ListUsers(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return p.users(users)
+
+	case "show":
+		userID, err := parseWithID(flag.NewFlagSet("users show", flag.ContinueOnError), args)
+		if err != nil {
+			return err
+		}
+
+		user, err := b.GetUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		return p.user(user)
+
+	case "activate", "deactivate":
+		fs := flag.NewFlagSet("users "+subcommand, flag.ContinueOnError)
+		ifMatch := fs.Int("if-match", 0, "only change the user if it still has this version")
+		userID, err := parseWithID(fs, args)
+		if err != nil {
+			return err
+		}
+
+		if err := b.SetUserActive(ctx, userID, subcommand == "activate", versionFlag(fs, *ifMatch)); err != nil {
+			return err
+		}
+
+		user, err := b.GetUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		return p.user(user)
+
+	default:
+		return fmt.Errorf("unknown users subcommand: %s", subcommand)
+	}
+}
+
+func runForms(ctx context.Context, b backend, p *printer, args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing forms subcommand: list, show, approve or reject")
+	}
+	subcommand, args := args[0], args[1:]
+
+	switch subcommand {
+	case "list":
+		fs := flag.NewFlagSet("forms list", flag.ContinueOnError)
+		expr := fs.String("filter", "", `filter such as "user_id=<id>,executor_id=<id>,status=pending"`)
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+
+		filter, err := parseFilter(*expr)
+		if err != nil {
+			return err
+		}
+
+		forms, err := b.ListForms(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return p.forms(forms)
+
+	case "show":
+		formID, err := parseWithID(flag.NewFlagSet("forms show", flag.ContinueOnError), args)
+		if err != nil {
+			return err
+		}
+
+		form, err := b.GetForm(ctx, formID)
+		if err != nil {
+			return err
+		}
+		return p.form(form)
+
+	case "approve", "reject":
+		fs := flag.NewFlagSet("forms "+subcommand, flag.ContinueOnError)
+		comment := fs.String("comment", "", "comment for the author")
+		ifMatch := fs.Int("if-match", 0, "only review the form if it still has this version")
+		formID, err := parseWithID(fs, args)
+		if err != nil {
+			return err
+		}
+
+		if err := b.ReviewForm(ctx, formID, subcommand == "approve", *comment, versionFlag(fs, *ifMatch)); err != nil {
+			return err
+		}
+
+		form, err := b.GetForm(ctx, formID)
+		if err != nil {
+			return err
+		}
+		return p.form(form)
+
+	default:
+		return fmt.Errorf("unknown forms subcommand: %s", subcommand)
+	}
+}
+
+func runAdmin(ctx context.Context, b backend, out io.Writer, args []string) error {
+	if len(args) == 0 || args[0] != "reset-password" {
+		return errors.New("unknown admin subcommand, expected reset-password")
+	}
+
+	fs := flag.NewFlagSet("admin reset-password", flag.ContinueOnError)
+	email := fs.String("email", "", "email of the user")
+	passwordStdin := fs.Bool("password-stdin", false, "read the new password from stdin")
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	password, err := readPassword("New password: ", *passwordStdin)
+	if err != nil {
+		return err
+	}
+	if !*passwordStdin {
+		confirmation, err := readPassword("Repeat the password: ", false)
+		if err != nil {
+			return err
+		}
+		if confirmation != password {
+			return errors.New("the passwords do not match")
+		}
+	}
+
+	if err := b.ResetPassword(ctx, *email, password); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Password of %s has been reset, all of their sessions are signed out\n", *email)
+	return nil
+}
+
+// parseFlags rejects positional arguments the subcommand does not take.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	return nil
+}
+
+// parseWithID reads the ID argument, with flags given before or after it.
+func parseWithID(fs *flag.FlagSet, args []string) (uuid.UUID, error) {
+	if err := fs.Parse(args); err != nil {
+		return uuid.Nil, err
+	}
+	if fs.NArg() == 0 {
+		return uuid.Nil, errors.New("missing id argument")
+	}
+
+	id, err := uuid.Parse(fs.Arg(0))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid id %q: %w", fs.Arg(0), err)
+	}
+
+	if err := parseFlags(fs, fs.Args()[1:]); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// versionFlag returns the expected version when -if-match was given.
+func versionFlag(fs *flag.FlagSet, version int) *int {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "if-match" {
+			set = true
+		}
+	})
+	if !set {
+		return nil
+	}
+	return &version
+}
+
+func readPassword(prompt string, fromStdin bool) (string, error) {
+	if fromStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		password := strings.TrimRight(line, "\r\n")
+		if password == "" {
+			return "", errors.New("empty password on stdin")
+		}
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("stdin is not a terminal, use -password-stdin")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(password), nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// credentials are cached in the user config directory after login, readable by the owner only.
+type credentials struct {
+	Server string `json:"server"`
+	Token  string `json:"token"`
+	Email  string `json:"email"`
+}
+
+func credentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(dir, "hrmatectl", "credentials.json"), nil
+}
+
+func loadCredentials() (*credentials, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &credentials{}, nil
+		}
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	var creds credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &creds, nil
+}
+
+func saveCredentials(creds *credentials) error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return nil
+}
+
+func removeCredentials() error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
+	return nil
+}
+
+// resolveCredentials picks the server from the flag, $HRMATE_SERVER or the cache, in that order.
+// The token comes from $HRMATE_TOKEN, or from the cache when it was issued by the same server.
+func resolveCredentials(server string) (*credentials, error) {
+	cached, err := loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if server == "" {
+		server = os.Getenv("HRMATE_SERVER")
+	}
+	if server == "" {
+		server = cached.Server
+	}
+	if server == "" {
+		server = defaultServer
+	}
+	server = strings.TrimRight(server, "/")
+
+	creds := &credentials{Server: server, Token: os.Getenv("HRMATE_TOKEN")}
+	if creds.Token == "" && cached.Server == server {
+		creds.Token = cached.Token
+		creds.Email = cached.Email
+	}
+
+	return creds, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+)
+
+// parseFilter reads a form filter such as "user_id=<id>,executor_id=<id>,status=pending".
+// The keys are the query parameters of the form list endpoints, each may be given once.
+func parseFilter(expr string) (*formservice.Filter, error) {
+	filter := &formservice.Filter{}
+	if strings.TrimSpace(expr) == "" {
+		return filter, nil
+	}
+
+	seen := make(map[string]bool)
+	for term := range strings.SplitSeq(expr, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(term), "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid filter term %q, expected key=value", term)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("filter key %s is given more than once", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "user_id":
+			userID, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid user_id: %w", err)
+			}
+			filter.UserID = &userID
+		case "executor_id":
+			executorID, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid executor_id: %w", err)
+			}
+			filter.ExecutorID = &executorID
+		case "status":
+			status := domain.FormStatus(strings.ToLower(value))
+			filter.FormStatus = &status
+			if err := filter.ValidateStatus(); err != nil {
+				return nil, fmt.Errorf("invalid status %q, expected pending, approved or rejected", value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown filter key %s, expected user_id, executor_id or status", key)
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,129 @@
+// Command hrmatectl manages users and forms of an hrmate server from the command line.
+//
+// It talks to the API with a cached token, or with -offline directly to the database
+// configured by the POSTGRES_* variables, for break-glass tasks when the API cannot be used.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const usage = `Usage: hrmatectl [flags] <command> [arguments]
+
+Commands:
+  login [-email E] [-password-stdin] [-token T]   sign in and cache the token
+  logout                                          forget the cached token
+  users list [-role R] [-active true|false] [-department D]
+  users show <id>
+  users activate <id> [-if-match VERSION]
+  users deactivate <id> [-if-match VERSION]
+  forms list [-filter "user_id=<id>,executor_id=<id>,status=pending"]
+  forms show <id>
+  forms approve <id> [-comment C] [-if-match VERSION]
+  forms reject <id> [-comment C] [-if-match VERSION]
+  admin reset-password -email E [-password-stdin]  offline only
+
+Flags:
+`
+
+type options struct {
+	server  string
+	output  string
+	offline bool
+}
+
+func main() {
+	opts := options{}
+	flag.StringVar(&opts.server, "server", "", "API base URL, defaults to $HRMATE_SERVER, the cached server or "+defaultServer)
+	flag.StringVar(&opts.output, "o", "table", "output format (table, json)")
+	flag.BoolVar(&opts.offline, "offline", false, "work on the database directly instead of the API")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if opts.output != outputTable && opts.output != outputJSON {
+		fail(fmt.Errorf("unknown output format: %s", opts.output))
+	}
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, opts, flag.Args(), os.Stdout); err != nil {
+		stop()
+		// The flag set has already printed the usage of the subcommand
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fail(err)
+	}
+}
+
+func run(ctx context.Context, opts options, args []string, out io.Writer) error {
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "login":
+		return login(ctx, opts, args, out)
+	case "logout":
+		return logout(out)
+	case "users", "forms", "admin":
+	default:
+		return fmt.Errorf("unknown command: %s", command)
+	}
+
+	b, closeBackend, err := newBackend(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	printer := &printer{w: out, format: opts.output}
+
+	switch command {
+	case "users":
+		return runUsers(ctx, b, printer, args)
+	case "forms":
+		return runForms(ctx, b, printer, args)
+	default:
+		return runAdmin(ctx, b, out, args)
+	}
+}
+
+// newBackend returns the database backend with -offline, the API backend otherwise.
+func newBackend(ctx context.Context, opts options) (backend, func(), error) {
+	if opts.offline {
+		db, err := newDBBackend(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, db.close, nil
+	}
+
+	creds, err := resolveCredentials(opts.server)
+	if err != nil {
+		return nil, nil, err
+	}
+	if creds.Token == "" {
+		return nil, nil, errors.New("not logged in, run hrmatectl login or set HRMATE_TOKEN")
+	}
+
+	return newAPIClient(creds.Server, creds.Token), func() {}, nil
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "hrmatectl: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/config"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/metrics"
+	"github.com/platonso/hrmate/internal/password"
+	"github.com/platonso/hrmate/internal/repository/postgres"
+	"github.com/platonso/hrmate/internal/service/auth"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+	"github.com/platonso/hrmate/internal/service/notification"
+	"github.com/platonso/hrmate/internal/service/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dbBackend works on the database with the services of the server and the
+// permissions of an administrator. It bypasses authentication, so it is meant
+// for operators with database access only.
+type dbBackend struct {
+	repo    *postgres.Repository
+	userSvc *user.Service
+	formSvc *formservice.Service
+	authSvc *auth.Service
+}
+
+func newDBBackend(ctx context.Context) (*dbBackend, error) {
+	dbCfg, err := config.NewDB()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	passwordCfg, err := config.NewPassword()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	// Service errors are logged, warnings are enough next to the command output
+	log, err := logger.New(os.Stderr, "warn", "text")
+	if err != nil {
+		return nil, err
+	}
+	slog.SetDefault(log)
+
+	repo, txMgr, err := postgres.NewRepository(ctx, dbCfg.GetDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the database: %w", err)
+	}
+
+	policy, err := password.NewPolicy(passwordCfg.MinLength, passwordCfg.BreachedListFile)
+	if err != nil {
+		repo.Close()
+		return nil, fmt.Errorf("failed to create password policy: %w", err)
+	}
+
+	hasher := password.NewHasher(
+		password.NewArgon2id(password.Argon2idParams{
+			Memory:      passwordCfg.Argon2Memory,
+			Iterations:  passwordCfg.Argon2Iterations,
+			Parallelism: passwordCfg.Argon2Parallelism,
+		}),
+		password.NewBcrypt(bcrypt.DefaultCost),
+	)
+
+	notificationSvc := notification.NewService(notification.LogSender{}, repo.Users)
+	formSvc := formservice.NewService(txMgr, repo.Forms, repo.Users, notificationSvc, metrics.New())
+
+	// Users are not deleted offline, so the legal holds are not needed
+	return &dbBackend{
+		repo:    repo,
+		userSvc: user.NewService(txMgr, repo.Users, repo.Sessions, formSvc, notificationSvc, hasher, policy, nil),
+		formSvc: formSvc,
+		// No tokens are issued offline, so the JWT settings are not needed
+		authSvc: auth.NewService(txMgr, repo.Users, repo.Sessions, hasher, policy, "", 0, 0),
+	}, nil
+}
+
+func (b *dbBackend) close() {
+	b.repo.Close()
+}
+
+// ListUsers lists every user, as the API does for administrators.
+func (b *dbBackend) ListUsers(ctx context.Context, filter userFilter) ([]userView, error) {
+	users, err := b.repo.Users.FindByRole(ctx, domain.RoleAdmin, domain.RoleHR, domain.RoleEmployee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	result := make([]userView, 0, len(users))
+	for i := range users {
+		view := toUserView(&users[i])
+		if filter.matches(view) {
+			result = append(result, view)
+		}
+	}
+	return result, nil
+}
+
+func (b *dbBackend) GetUser(ctx context.Context, userID uuid.UUID) (*userView, error) {
+	u, err := b.userSvc.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := toUserView(u)
+	return &view, nil
+}
+
+func (b *dbBackend) SetUserActive(ctx context.Context, userID uuid.UUID, isActive bool, expectedVersion *int) error {
+	_, err := b.userSvc.ChangeActiveStatus(ctx, userID, isActive, expectedVersion)
+	return err
+}
+
+func (b *dbBackend) ListForms(ctx context.Context, filter *formservice.Filter) ([]formView, error) {
+	forms, err := b.formSvc.GetForms(ctx, filter, uuid.Nil, domain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return b.formViews(ctx, forms)
+}
+
+func (b *dbBackend) GetForm(ctx context.Context, formID uuid.UUID) (*formView, error) {
+	form, err := b.formSvc.GetForm(ctx, formID, uuid.Nil, domain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := b.formViews(ctx, []domain.Form{*form})
+	if err != nil {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+// ReviewForm is refused offline, as a review is the decision of the assigned HR
+// and would not be attributed to anyone.
+func (b *dbBackend) ReviewForm(context.Context, uuid.UUID, bool, string, *int) error {
+	return errors.New("forms are reviewed by the assigned HR through the API, approve and reject are not available with -offline")
+}
+
+func (b *dbBackend) ResetPassword(ctx context.Context, email, password string) error {
+	_, err := b.authSvc.ResetPassword(ctx, email, password)
+	return err
+}
+
+func (b *dbBackend) formViews(ctx context.Context, forms []domain.Form) ([]formView, error) {
+	userIDs := make([]uuid.UUID, 0, len(forms)*2)
+	for _, form := range forms {
+		userIDs = append(userIDs, form.UserID, form.ExecutorID)
+	}
+
+	users, err := b.userSvc.GetUsersByIDs(ctx, userIDs, uuid.Nil, domain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		emails[u.ID] = u.Email
+	}
+
+	views := make([]formView, len(forms))
+	for i, form := range forms {
+		views[i] = formView{
+			ID:          form.ID,
+			Title:       form.Title,
+			Description: form.Description,
+			StartDate:   form.StartDate,
+			EndDate:     form.EndDate,
+			CreatedAt:   form.CreatedAt,
+			ReviewedAt:  form.ReviewedAt,
+			Status:      form.Status,
+			Comment:     form.Comment,
+			Author:      emails[form.UserID],
+			Executor:    emails[form.ExecutorID],
+			Version:     form.Version,
+		}
+	}
+	return views, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p *printer) users(users []userView) error {
+	if p.format == outputJSON {
+		return p.json(users)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tROLE\tNAME\tEMAIL\tDEPARTMENT\tACTIVE\tVERSION")
+	for _, u := range users {
+		fmt.Fprintf(tw, "%s\t%s\t%s %s\t%s\t%s\t%t\t%d\n",
+			u.ID, u.Role, u.FirstName, u.LastName, u.Email, orDash(u.Department), u.IsActive, u.Version)
+	}
+	return tw.Flush()
+}
+
+func (p *printer) user(u *userView) error {
+	if p.format == outputJSON {
+		return p.json(u)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", u.ID)
+	fmt.Fprintf(tw, "Role:\t%s\n", u.Role)
+	fmt.Fprintf(tw, "Name:\t%s %s\n", u.FirstName, u.LastName)
+	fmt.Fprintf(tw, "Position:\t%s\n", u.Position)
+	fmt.Fprintf(tw, "Email:\t%s\n", u.Email)
+	fmt.Fprintf(tw, "Department:\t%s\n", orDash(u.Department))
+	fmt.Fprintf(tw, "Active:\t%t\n", u.IsActive)
+	fmt.Fprintf(tw, "Version:\t%d\n", u.Version)
+	return tw.Flush()
+}
+
+func (p *printer) forms(forms []formView) error {
+	if p.format == outputJSON {
+		return p.json(forms)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tTITLE\tAUTHOR\tEXECUTOR\tCREATED\tVERSION")
+	for _, f := range forms {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			f.ID, f.Status, f.Title, orDash(f.Author), orDash(f.Executor), f.CreatedAt.Format(time.DateTime), f.Version)
+	}
+	return tw.Flush()
+}
+
+func (p *printer) form(f *formView) error {
+	if p.format == outputJSON {
+		return p.json(f)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", f.ID)
+	fmt.Fprintf(tw, "Title:\t%s\n", f.Title)
+	fmt.Fprintf(tw, "Description:\t%s\n", f.Description)
+	fmt.Fprintf(tw, "Period:\t%s - %s\n", formatDate(f.StartDate), formatDate(f.EndDate))
+	fmt.Fprintf(tw, "Status:\t%s\n", f.Status)
+	fmt.Fprintf(tw, "Author:\t%s\n", orDash(f.Author))
+	fmt.Fprintf(tw, "Executor:\t%s\n", orDash(f.Executor))
+	fmt.Fprintf(tw, "Created:\t%s\n", f.CreatedAt.Format(time.DateTime))
+	fmt.Fprintf(tw, "Reviewed:\t%s\n", formatTime(f.ReviewedAt))
+	if f.Comment != nil {
+		fmt.Fprintf(tw, "Comment:\t%s\n", *f.Comment)
+	}
+	fmt.Fprintf(tw, "Version:\t%d\n", f.Version)
+	return tw.Flush()
+}
+
+func (p *printer) json(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateOnly)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
@@ -4,83 +4,491 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/platonso/hrmate/internal/config"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/grpcapi"
 	"github.com/platonso/hrmate/internal/handler"
+	"github.com/platonso/hrmate/internal/handler/graphql"
+	"github.com/platonso/hrmate/internal/handler/openapi"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/metrics"
+	"github.com/platonso/hrmate/internal/password"
 	"github.com/platonso/hrmate/internal/repository/postgres"
+	"github.com/platonso/hrmate/internal/service/audit"
 	"github.com/platonso/hrmate/internal/service/auth"
+	"github.com/platonso/hrmate/internal/service/department"
 	"github.com/platonso/hrmate/internal/service/form"
+	"github.com/platonso/hrmate/internal/service/health"
+	"github.com/platonso/hrmate/internal/service/idempotency"
+	"github.com/platonso/hrmate/internal/service/notification"
+	"github.com/platonso/hrmate/internal/service/privacy"
+	"github.com/platonso/hrmate/internal/service/ratelimit"
+	"github.com/platonso/hrmate/internal/service/session"
+	"github.com/platonso/hrmate/internal/service/sso"
+	"github.com/platonso/hrmate/internal/service/token"
 	"github.com/platonso/hrmate/internal/service/user"
+	"github.com/platonso/hrmate/internal/tlscert"
+	"github.com/platonso/hrmate/internal/tracing"
+	"github.com/platonso/hrmate/migrations"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
 )
 
 type Application struct {
 	config *config.Config
+	logger *slog.Logger
 	repo   *postgres.Repository
 	server *http.Server
+	// metricsServer serves /metrics on its own port, nil when it shares the API port
+	metricsServer *http.Server
+	// grpcServer is nil when GRPC_ADDR is not set
+	grpcServer *grpc.Server
+
+	idempotency *idempotency.Service
+	rateLimit   *ratelimit.Service
+	health      *health.Service
+	// certs is nil when TLS is terminated in front of the application
+	certs *tlscert.Reloader
+	// shutdownTracing flushes the spans that have not been exported yet
+	shutdownTracing func(context.Context) error
+
+	// Background jobs are stopped before the database connection is closed
+	workers *workers
 }
 
-func New(ctx context.Context, cfg *config.Config) (*Application, error) {
+func New(ctx context.Context, cfg *config.Config, log *slog.Logger) (*Application, error) {
+	ctx = logger.WithContext(ctx, log)
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Settings{
+		Exporter:    cfg.Tracing.Exporter,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
 	postgresRepo, txMgr, err := postgres.NewRepository(ctx, cfg.Postgres.GetDSN())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 
-	userSvc := user.NewService(postgresRepo.Users)
-	authSvc := auth.NewService(txMgr, postgresRepo.Users, cfg.JWTSecret)
-	formSvc := form.NewService(txMgr, postgresRepo.Forms, postgresRepo.Users)
+	passwordPolicy, err := password.NewPolicy(cfg.Password.MinLength, cfg.Password.BreachedListFile)
+	if err != nil {
+		postgresRepo.Close()
+		return nil, fmt.Errorf("failed to create password policy: %w", err)
+	}
+
+	passwordHasher := password.NewHasher(
+		password.NewArgon2id(password.Argon2idParams{
+			Memory:      cfg.Password.Argon2Memory,
+			Iterations:  cfg.Password.Argon2Iterations,
+			Parallelism: cfg.Password.Argon2Parallelism,
+		}),
+		password.NewBcrypt(bcrypt.DefaultCost),
+	)
+
+	appMetrics := metrics.New(
+		metrics.NewPoolCollector(postgresRepo.Stat),
+		metrics.NewWorkloadCollector(postgresRepo.Users),
+	)
+
+	authSvc := auth.NewService(
+		txMgr,
+		postgresRepo.Users,
+		postgresRepo.Sessions,
+		passwordHasher,
+		passwordPolicy,
+		cfg.JWTSecret,
+		cfg.SessionTTL,
+		cfg.Impersonation.TTL,
+	)
+	sessionSvc := session.NewService(postgresRepo.Sessions, postgresRepo.Users)
+	var sender notification.Sender = notification.LogSender{}
+	if cfg.SMTP.Enabled() {
+		sender = &notification.SMTPSender{
+			Addr:     cfg.SMTP.Addr(),
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		}
+	}
+	notificationSvc := notification.NewService(sender, postgresRepo.Users)
+	formSvc := form.NewService(txMgr, postgresRepo.Forms, postgresRepo.Users, notificationSvc, appMetrics)
+	legalHolds, err := cfg.Privacy.LegalHolds()
+	if err != nil {
+		postgresRepo.Close()
+		return nil, err
+	}
+	userSvc := user.NewService(txMgr, postgresRepo.Users, postgresRepo.Sessions, formSvc, notificationSvc, passwordHasher, passwordPolicy, legalHolds)
+	departmentSvc := department.NewService(txMgr, postgresRepo.Departments, postgresRepo.Users)
+	tokenSvc := token.NewService(postgresRepo.Tokens, postgresRepo.Users)
+	auditSvc := audit.NewService(postgresRepo.Audit)
+
+	privacySvc := privacy.NewService(
+		txMgr,
+		postgresRepo.Users,
+		postgresRepo.Forms,
+		postgresRepo.Audit,
+		[]privacy.RecordRepository{postgresRepo.Sessions, postgresRepo.Tokens, postgresRepo.Identities, postgresRepo.Idempotency},
+		userSvc,
+		legalHolds,
+	)
+	idempotencySvc := idempotency.NewService(postgresRepo.Idempotency, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout)
+
+	rateLimitSvc, err := newRateLimitService(cfg.RateLimit, txMgr, postgresRepo)
+	if err != nil {
+		postgresRepo.Close()
+		return nil, err
+	}
+
+	schemaVersion, err := migrations.LatestVersion()
+	if err != nil {
+		postgresRepo.Close()
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	appWorkers := newWorkers(logger.WithContext(context.Background(), log))
+	healthSvc := health.NewService(postgresRepo, appWorkers, schemaVersion)
 
 	if err := authSvc.ImplementAdmin(ctx, cfg.AdminEmail, cfg.AdminPassword); err != nil {
 		postgresRepo.Close()
 		return nil, fmt.Errorf("failed to implement admin: %w", err)
 	}
 
-	router := handler.NewRouter(authSvc, userSvc, formSvc)
+	var ssoSvc handler.SSOProvider
+	if cfg.OIDC.Enabled() {
+		roles, err := sso.NewRoleMapper(cfg.OIDC.RoleClaim, cfg.OIDC.RoleMapping, cfg.OIDC.DefaultRole)
+		if err != nil {
+			postgresRepo.Close()
+			return nil, fmt.Errorf("invalid OIDC role mapping: %w", err)
+		}
+
+		ssoSvc = sso.NewService(
+			sso.Settings{
+				IssuerURL:    cfg.OIDC.IssuerURL,
+				ClientID:     cfg.OIDC.ClientID,
+				ClientSecret: cfg.OIDC.ClientSecret,
+				RedirectURL:  cfg.OIDC.RedirectURL,
+				Scopes:       cfg.OIDC.Scopes,
+				StateTTL:     cfg.OIDC.StateTTL,
+			},
+			roles,
+			txMgr,
+			postgresRepo.Users,
+			postgresRepo.Identities,
+			postgresRepo.AuthStates,
+			authSvc,
+		)
+	}
+
+	spec, err := openapi.Load(ctx)
+	if err != nil {
+		postgresRepo.Close()
+		return nil, err
+	}
+
+	docs, err := openapi.NewHandler(spec)
+	if err != nil {
+		postgresRepo.Close()
+		return nil, err
+	}
+
+	validator, err := openapi.NewValidator(spec, openapi.ValidatorOptions{
+		Requests:  cfg.OpenAPI.ValidateRequests,
+		Responses: cfg.OpenAPI.ValidateResponses,
+	})
+	if err != nil {
+		postgresRepo.Close()
+		return nil, fmt.Errorf("failed to create openapi validator: %w", err)
+	}
+
+	graphQL, err := graphql.NewHandler(userSvc, formSvc, graphql.Limits{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	})
+	if err != nil {
+		postgresRepo.Close()
+		return nil, err
+	}
+
+	trustedProxies, err := cfg.HTTP.TrustedProxyPrefixes()
+	if err != nil {
+		postgresRepo.Close()
+		return nil, err
+	}
+
+	var metricsHandler http.Handler
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		if cfg.Metrics.Addr == "" {
+			metricsHandler = appMetrics.Handler()
+		} else {
+			mux := http.NewServeMux()
+			mux.Handle("GET /metrics", appMetrics.Handler())
+			metricsServer = &http.Server{
+				Addr:              cfg.Metrics.Addr,
+				Handler:           mux,
+				ErrorLog:          slog.NewLogLogger(log.Handler(), slog.LevelWarn),
+				ReadHeaderTimeout: cfg.HTTP.ReadTimeout,
+			}
+		}
+	}
+
+	router := handler.NewRouter(
+		handler.Services{
+			Auth:    authSvc,
+			User:    userSvc,
+			Form:    formSvc,
+			Token:   tokenSvc,
+			Session: sessionSvc,
+			Audit:   auditSvc,
+			Health:  healthSvc,
+			Privacy: privacySvc,
+
+			Department: departmentSvc,
+
+			Idempotency: idempotencySvc,
+			RateLimit:   rateLimitSvc,
+			SSO:         ssoSvc,
+		},
+		handler.Options{
+			AllowImpersonatedWrites: cfg.Impersonation.AllowWrites,
+			CORS: handler.CORSOptions{
+				AllowedOrigins:   cfg.HTTP.CORSAllowedOrigins,
+				AllowedMethods:   cfg.HTTP.CORSAllowedMethods,
+				AllowCredentials: cfg.HTTP.CORSAllowCredentials,
+			},
+			Legacy: handler.LegacyOptions{
+				Enabled:      cfg.API.LegacyRoutes,
+				DeprecatedAt: cfg.API.LegacyDeprecatedAt,
+				Sunset:       cfg.API.LegacySunset,
+			},
+			HSTSMaxAge:     cfg.HTTP.HSTSMaxAge,
+			MaxBodyBytes:   cfg.HTTP.MaxBodyBytes,
+			TrustedProxies: trustedProxies,
+			Logger:         log,
+			Metrics:        appMetrics,
+			MetricsHandler: metricsHandler,
+			Docs:           docs,
+			Validator:      validator,
+			GraphQL:        graphQL,
+		},
+	)
+
+	routes := router.Routes()
+
+	// Every registered route has to be documented, so the spec cannot silently drift
+	if err := openapi.CheckRoutes(spec, routes); err != nil {
+		postgresRepo.Close()
+		return nil, err
+	}
+
+	var certs *tlscert.Reloader
+	if cfg.HTTP.TLSEnabled() {
+		certs, err = tlscert.NewReloader(cfg.HTTP.TLSCertFile, cfg.HTTP.TLSKeyFile)
+		if err != nil {
+			postgresRepo.Close()
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+	}
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.HTTP.Port,
-		Handler:      router.Routes(),
+		Handler:      routes,
+		ErrorLog:     slog.NewLogLogger(log.Handler(), slog.LevelWarn),
 		ReadTimeout:  cfg.HTTP.ReadTimeout,
 		WriteTimeout: cfg.HTTP.WriteTimeout,
 		IdleTimeout:  cfg.HTTP.IdleTimeout,
 	}
+	if certs != nil {
+		srv.TLSConfig = certs.TLSConfig()
+	}
+
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Addr != "" {
+		grpcOpts := grpcapi.Options{
+			AllowImpersonatedWrites: cfg.Impersonation.AllowWrites,
+			Reflection:              cfg.GRPC.Reflection,
+			Logger:                  log,
+		}
+		if certs != nil {
+			grpcOpts.TLSConfig = certs.TLSConfig()
+		}
+
+		grpcServer = grpcapi.NewServer(grpcapi.Services{
+			Auth:      authSvc,
+			User:      userSvc,
+			Form:      formSvc,
+			Token:     tokenSvc,
+			Session:   sessionSvc,
+			RateLimit: rateLimitSvc,
+			Audit:     auditSvc,
+		}, grpcOpts)
+	}
 
 	app := &Application{
-		config: cfg,
-		repo:   postgresRepo,
-		server: srv,
+		config:        cfg,
+		logger:        log,
+		repo:          postgresRepo,
+		server:        srv,
+		metricsServer: metricsServer,
+		grpcServer:    grpcServer,
+		idempotency:   idempotencySvc,
+		rateLimit:     rateLimitSvc,
+		health:        healthSvc,
+		certs:         certs,
+		workers:       appWorkers,
+
+		shutdownTracing: shutdownTracing,
 	}
 
 	return app, nil
 }
 
 func (app *Application) Start(errChan chan<- error) {
-	log.Printf("Starting server on port %s", app.config.HTTP.Port)
+	app.workers.Go(func(ctx context.Context) {
+		app.idempotency.RunCleanup(ctx, app.config.Idempotency.CleanupInterval)
+	})
+
+	if app.metricsServer != nil {
+		go func() {
+			app.logger.Info("starting metrics server", "addr", app.metricsServer.Addr)
+			if err := app.metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errChan <- fmt.Errorf("metrics server: %w", err)
+			}
+		}()
+	}
+
+	if app.grpcServer != nil {
+		go func() {
+			lis, err := net.Listen("tcp", app.config.GRPC.Addr)
+			if err != nil {
+				errChan <- fmt.Errorf("grpc server: %w", err)
+				return
+			}
+
+			app.logger.Info("starting gRPC server", "addr", app.config.GRPC.Addr, "tls", app.certs != nil)
+			if err := app.grpcServer.Serve(lis); err != nil {
+				errChan <- fmt.Errorf("grpc server: %w", err)
+			}
+		}()
+	}
+
+	app.workers.Go(func(ctx context.Context) {
+		app.rateLimit.RunCleanup(ctx, app.config.RateLimit.CleanupInterval)
+	})
 
-	if err := app.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+	if app.certs != nil {
+		app.workers.Go(func(ctx context.Context) {
+			app.certs.Run(ctx, app.config.HTTP.TLSReloadInterval)
+		})
+	}
+
+	app.logger.Info("starting server", "port", app.config.HTTP.Port, "tls", app.certs != nil)
+
+	var err error
+	if app.certs != nil {
+		// The certificate comes from TLSConfig.GetCertificate
+		err = app.server.ListenAndServeTLS("", "")
+	} else {
+		err = app.server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		errChan <- err
 	}
 }
 
 func (app *Application) Stop(ctx context.Context) error {
-	log.Println("Initiating graceful shutdown...")
+	app.logger.Info("initiating graceful shutdown")
 	var shutdownErr error
 
+	// Fail readiness first and give load balancers time to stop sending requests
+	app.health.Drain()
+	if delay := app.config.HTTP.ShutdownDelay; delay > 0 {
+		app.logger.Info("draining traffic", "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
 	if app.server != nil {
-		log.Println("Shutting down HTTP server...")
+		app.logger.Info("shutting down HTTP server")
 		if err := app.server.Shutdown(ctx); err != nil {
 			shutdownErr = fmt.Errorf("server shutdown failed: %w", err)
-			log.Printf("Error during server shutdown: %v", err)
+			app.logger.Error("server shutdown failed", "error", err)
 		}
 	}
 
-	log.Println("Closing database connection...")
+	if app.grpcServer != nil {
+		app.logger.Info("shutting down gRPC server")
+		stopGRPC(ctx, app.grpcServer)
+	}
+
+	if app.metricsServer != nil {
+		if err := app.metricsServer.Shutdown(ctx); err != nil {
+			app.logger.Error("metrics server shutdown failed", "error", err)
+		}
+	}
+
+	app.logger.Info("stopping background jobs")
+	app.workers.Stop()
+
+	app.logger.Info("closing database connection")
 	app.repo.Close()
 
+	if err := app.shutdownTracing(ctx); err != nil {
+		app.logger.Error("failed to flush traces", "error", err)
+	}
+
 	if shutdownErr == nil {
-		log.Println("Graceful shutdown completed successfully")
+		app.logger.Info("graceful shutdown completed")
 	}
 
 	return shutdownErr
 }
+
+func newRateLimitService(cfg config.RateLimitConfig, txMgr trm.Manager, repo *postgres.Repository) (*ratelimit.Service, error) {
+	var store ratelimit.Store
+	switch cfg.Store {
+	case "memory":
+		store = ratelimit.NewMemoryStore()
+	case "postgres":
+		store = ratelimit.NewPostgresStore(txMgr, repo.RateLimits)
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+
+	limit := func(requests int) domain.RateLimit {
+		return domain.RateLimit{Requests: requests, Period: cfg.Period}
+	}
+
+	return ratelimit.NewService(store, map[domain.RateLimitGroup]domain.RateLimit{
+		domain.RateLimitGroupAuth:     limit(cfg.Auth),
+		domain.RateLimitGroupEmployee: limit(cfg.Employee),
+		domain.RateLimitGroupHR:       limit(cfg.HR),
+		domain.RateLimitGroupAdmin:    limit(cfg.Admin),
+	}), nil
+}
+
+// stopGRPC lets running calls finish and cancels them once ctx is done.
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
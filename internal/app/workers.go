@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// workers runs the background jobs and tells the readiness probe whether all of them are alive.
+type workers struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    sync.WaitGroup
+	started atomic.Int32
+	running atomic.Int32
+}
+
+func newWorkers(ctx context.Context) *workers {
+	ctx, cancel := context.WithCancel(ctx)
+	return &workers{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go runs the job until the workers are stopped.
+func (w *workers) Go(job func(ctx context.Context)) {
+	w.started.Add(1)
+	w.running.Add(1)
+
+	w.done.Go(func() {
+		defer w.running.Add(-1)
+		job(w.ctx)
+	})
+}
+
+// Running reports whether the workers have been started and none of them has exited.
+func (w *workers) Running() bool {
+	started := w.started.Load()
+	return started > 0 && w.running.Load() == started
+}
+
+// Stop cancels the jobs and waits for them to return.
+func (w *workers) Stop() {
+	w.cancel()
+	w.done.Wait()
+}
@@ -1,9 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -21,14 +27,155 @@ type HTTPConfig struct {
 	ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT" env-default:"15s"`
 	WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT" env-default:"15s"`
 	IdleTimeout  time.Duration `env:"HTTP_IDLE_TIMEOUT" env-default:"60s"`
+	// ShutdownDelay keeps serving after /readyz starts failing, so load balancers can drain the instance
+	ShutdownDelay time.Duration `env:"HTTP_SHUTDOWN_DELAY" env-default:"5s"`
+	// ShutdownTimeout bounds the whole graceful shutdown, including the delay
+	ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"20s"`
+	MaxBodyBytes    int64         `env:"HTTP_MAX_BODY_BYTES" env-default:"1048576"`
+	// TrustedProxies are addresses or CIDR ranges of reverse proxies; the client address
+	// is taken from X-Forwarded-For or X-Real-IP only on requests coming from them
+	TrustedProxies []string `env:"HTTP_TRUSTED_PROXIES"`
+
+	CORSAllowedOrigins   []string `env:"HTTP_CORS_ALLOWED_ORIGINS" env-default:"*"`
+	CORSAllowedMethods   []string `env:"HTTP_CORS_ALLOWED_METHODS" env-default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
+	CORSAllowCredentials bool     `env:"HTTP_CORS_ALLOW_CREDENTIALS" env-default:"false"`
+
+	// HSTSMaxAge is sent to clients connecting over HTTPS, zero disables HSTS
+	HSTSMaxAge time.Duration `env:"HTTP_HSTS_MAX_AGE" env-default:"8760h"`
+
+	// The server speaks HTTPS when both files are set. They are re-read when they
+	// change, so renewed certificates are picked up without a restart.
+	TLSCertFile       string        `env:"HTTP_TLS_CERT_FILE"`
+	TLSKeyFile        string        `env:"HTTP_TLS_KEY_FILE"`
+	TLSReloadInterval time.Duration `env:"HTTP_TLS_RELOAD_INTERVAL" env-default:"1m"`
+}
+
+type GRPCConfig struct {
+	// Addr enables the gRPC API on its own listener, e.g. ":9000". It uses the
+	// HTTP_TLS_* certificate when the HTTP server does.
+	Addr       string `env:"GRPC_ADDR"`
+	Reflection bool   `env:"GRPC_REFLECTION" env-default:"true"`
+}
+
+type GraphQLConfig struct {
+	MaxDepth int `env:"GRAPHQL_MAX_DEPTH" env-default:"6"`
+	// MaxComplexity counts the fields a query resolves, every list as 10 items
+	MaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" env-default:"5000"`
+}
+
+type OIDCConfig struct {
+	IssuerURL    string            `env:"OIDC_ISSUER_URL"`
+	ClientID     string            `env:"OIDC_CLIENT_ID"`
+	ClientSecret string            `env:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string            `env:"OIDC_REDIRECT_URL"`
+	Scopes       []string          `env:"OIDC_SCOPES" env-default:"openid,profile,email"`
+	RoleClaim    string            `env:"OIDC_ROLE_CLAIM" env-default:"groups"`
+	RoleMapping  map[string]string `env:"OIDC_ROLE_MAPPING"`
+	DefaultRole  string            `env:"OIDC_DEFAULT_ROLE" env-default:"employee"`
+	StateTTL     time.Duration     `env:"OIDC_STATE_TTL" env-default:"10m"`
+}
+
+type ImpersonationConfig struct {
+	TTL         time.Duration `env:"IMPERSONATION_TTL" env-default:"15m"`
+	AllowWrites bool          `env:"IMPERSONATION_ALLOW_WRITES" env-default:"false"`
+}
+
+type PasswordConfig struct {
+	MinLength         int    `env:"PASSWORD_MIN_LENGTH" env-default:"10"`
+	BreachedListFile  string `env:"PASSWORD_BREACHED_LIST_FILE"`
+	Argon2Memory      uint32 `env:"ARGON2_MEMORY_KIB" env-default:"65536"`
+	Argon2Iterations  uint32 `env:"ARGON2_ITERATIONS" env-default:"3"`
+	Argon2Parallelism uint8  `env:"ARGON2_PARALLELISM" env-default:"2"`
+}
+
+type OpenAPIConfig struct {
+	ValidateRequests bool `env:"OPENAPI_VALIDATE_REQUESTS" env-default:"true"`
+	// Intended for tests and local development only
+	ValidateResponses bool `env:"OPENAPI_VALIDATE_RESPONSES" env-default:"false"`
+}
+
+type IdempotencyConfig struct {
+	TTL             time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	LockTimeout     time.Duration `env:"IDEMPOTENCY_LOCK_TIMEOUT" env-default:"1m"`
+	CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h"`
+}
+
+type PrivacyConfig struct {
+	// LegalHoldUserIDs are users whose data must be kept, e.g. for litigation or an
+	// audit; erasing them is refused until they are removed from the list
+	LegalHoldUserIDs []string `env:"PRIVACY_LEGAL_HOLD_USER_IDS"`
+}
+
+// SMTPConfig is the mail server notifications are delivered through. Without a
+// host they are only written to the log and users cannot be invited.
+type SMTPConfig struct {
+	Host     string `env:"SMTP_HOST"`
+	Port     string `env:"SMTP_PORT" env-default:"587"`
+	Username string `env:"SMTP_USERNAME"`
+	Password string `env:"SMTP_PASSWORD"`
+	From     string `env:"SMTP_FROM"`
+}
+
+type LogConfig struct {
+	Level  string `env:"LOG_LEVEL" env-default:"info"`
+	Format string `env:"LOG_FORMAT" env-default:"json"`
+}
+
+type MetricsConfig struct {
+	// Enabled without Addr serves /metrics on the API port without authentication
+	Enabled bool `env:"METRICS_ENABLED" env-default:"false"`
+	// Addr moves /metrics to a separate listener, e.g. ":9090", so it is not exposed with the API
+	Addr string `env:"METRICS_ADDR"`
+}
+
+type APIConfig struct {
+	// LegacyRoutes keeps serving the v1 routes without the /api/v1 prefix
+	LegacyRoutes       bool      `env:"API_LEGACY_ROUTES" env-default:"true"`
+	LegacyDeprecatedAt time.Time `env:"API_LEGACY_DEPRECATED_AT" env-layout:"2006-01-02" env-default:"2026-10-19"`
+	// LegacySunset is the announced removal date of the legacy routes, unset until decided
+	LegacySunset time.Time `env:"API_LEGACY_SUNSET" env-layout:"2006-01-02"`
+}
+
+type RateLimitConfig struct {
+	// Store is memory or postgres; postgres shares the limits between replicas
+	Store  string        `env:"RATE_LIMIT_STORE" env-default:"memory"`
+	Period time.Duration `env:"RATE_LIMIT_PERIOD" env-default:"1m"`
+	// Requests allowed per period in each route group, 0 disables the limit
+	Auth            int           `env:"RATE_LIMIT_AUTH" env-default:"10"`
+	Employee        int           `env:"RATE_LIMIT_EMPLOYEE" env-default:"60"`
+	HR              int           `env:"RATE_LIMIT_HR" env-default:"300"`
+	Admin           int           `env:"RATE_LIMIT_ADMIN" env-default:"300"`
+	CleanupInterval time.Duration `env:"RATE_LIMIT_CLEANUP_INTERVAL" env-default:"10m"`
+}
+
+type TracingConfig struct {
+	// Exporter is none, otlp or stdout; the OTLP endpoint comes from OTEL_EXPORTER_OTLP_ENDPOINT
+	Exporter    string  `env:"OTEL_TRACES_EXPORTER" env-default:"none"`
+	ServiceName string  `env:"OTEL_SERVICE_NAME" env-default:"hrmate"`
+	SampleRatio float64 `env:"OTEL_TRACES_SAMPLE_RATIO" env-default:"1"`
 }
 
 type Config struct {
 	HTTP          HTTPConfig
+	GRPC          GRPCConfig
+	GraphQL       GraphQLConfig
+	Log           LogConfig
+	Metrics       MetricsConfig
+	Tracing       TracingConfig
+	RateLimit     RateLimitConfig
+	API           APIConfig
 	Postgres      PostgresConfig
-	JWTSecret     string `env:"JWT_SECRET" env-required:"true"`
-	AdminEmail    string `env:"ADMIN_EMAIL" env-required:"true"`
-	AdminPassword string `env:"ADMIN_PASSWORD" env-required:"true"`
+	OIDC          OIDCConfig
+	Impersonation ImpersonationConfig
+	Password      PasswordConfig
+	OpenAPI       OpenAPIConfig
+	Idempotency   IdempotencyConfig
+	Privacy       PrivacyConfig
+	SMTP          SMTPConfig
+	JWTSecret     string        `env:"JWT_SECRET" env-required:"true"`
+	SessionTTL    time.Duration `env:"SESSION_TTL" env-default:"168h"`
+	AdminEmail    string        `env:"ADMIN_EMAIL" env-required:"true"`
+	AdminPassword string        `env:"ADMIN_PASSWORD" env-required:"true"`
 }
 
 func New() (*Config, error) {
@@ -38,6 +185,26 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	if err := cfg.HTTP.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Password.validate(); err != nil {
+		return nil, err
+	}
+	// The cleanups run on tickers, which panic on a non-positive interval
+	if cfg.RateLimit.CleanupInterval <= 0 {
+		return nil, errors.New("RATE_LIMIT_CLEANUP_INTERVAL must be positive")
+	}
+	if cfg.Idempotency.CleanupInterval <= 0 {
+		return nil, errors.New("IDEMPOTENCY_CLEANUP_INTERVAL must be positive")
+	}
+	if _, err := cfg.Privacy.LegalHolds(); err != nil {
+		return nil, err
+	}
+	if cfg.SMTP.Enabled() && cfg.SMTP.From == "" {
+		return nil, errors.New("SMTP_FROM is required with SMTP_HOST")
+	}
+
 	return &cfg, nil
 }
 
@@ -51,6 +218,22 @@ func NewDB() (*PostgresConfig, error) {
 	return &cfg, nil
 }
 
+// NewPassword reads the password settings alone, for tools that hash passwords
+// without running the server.
+func NewPassword() (*PasswordConfig, error) {
+	var cfg PasswordConfig
+
+	if err := cleanenv.ReadEnv(&cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
 func (c *PostgresConfig) GetDSN() string {
 	return fmt.Sprintf("postgresql://%s:%s@%s:%s/%s",
 		c.User,
@@ -60,3 +243,95 @@ func (c *PostgresConfig) GetDSN() string {
 		c.Database,
 	)
 }
+
+// Enabled reports whether single sign-on is configured.
+func (c *OIDCConfig) Enabled() bool {
+	return c.IssuerURL != "" && c.ClientID != ""
+}
+
+// Enabled reports whether notifications are delivered by email.
+func (c *SMTPConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// Addr is the host:port of the mail server.
+func (c *SMTPConfig) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// minArgon2Memory is the least memory OWASP recommends for argon2id, in KiB
+const minArgon2Memory = 19 * 1024
+
+func (c *PasswordConfig) validate() error {
+	if c.Argon2Memory < minArgon2Memory {
+		return fmt.Errorf("ARGON2_MEMORY_KIB must be at least %d", minArgon2Memory)
+	}
+	// argon2 panics on zero iterations or parallelism
+	if c.Argon2Iterations < 1 {
+		return errors.New("ARGON2_ITERATIONS must be positive")
+	}
+	if c.Argon2Parallelism < 1 {
+		return errors.New("ARGON2_PARALLELISM must be positive")
+	}
+	return nil
+}
+
+// TLSEnabled reports whether the server terminates TLS itself.
+func (c *HTTPConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSKeyFile != ""
+}
+
+func (c *HTTPConfig) validate() error {
+	if c.TLSEnabled() && (c.TLSCertFile == "" || c.TLSKeyFile == "") {
+		return errors.New("HTTP_TLS_CERT_FILE and HTTP_TLS_KEY_FILE must be set together")
+	}
+	// Browsers refuse credentialed responses to a wildcard origin
+	if c.CORSAllowCredentials && slices.Contains(c.CORSAllowedOrigins, "*") {
+		return errors.New("HTTP_CORS_ALLOW_CREDENTIALS requires explicit HTTP_CORS_ALLOWED_ORIGINS")
+	}
+	if c.MaxBodyBytes <= 0 {
+		return errors.New("HTTP_MAX_BODY_BYTES must be positive")
+	}
+	if c.TLSReloadInterval <= 0 {
+		return errors.New("HTTP_TLS_RELOAD_INTERVAL must be positive")
+	}
+	if _, err := c.TrustedProxyPrefixes(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TrustedProxyPrefixes parses HTTP_TRUSTED_PROXIES, a single address is a prefix of its full length.
+func (c *HTTPConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, s := range c.TrustedProxies {
+		s = strings.TrimSpace(s)
+		if strings.Contains(s, "/") {
+			prefix, err := netip.ParsePrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("HTTP_TRUSTED_PROXIES: invalid range %q", s)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP_TRUSTED_PROXIES: invalid address %q", s)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// LegalHolds parses PRIVACY_LEGAL_HOLD_USER_IDS.
+func (c *PrivacyConfig) LegalHolds() ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(c.LegalHoldUserIDs))
+	for _, s := range c.LegalHoldUserIDs {
+		id, err := uuid.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("PRIVACY_LEGAL_HOLD_USER_IDS: invalid user id %q", s)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntry records a state-changing request. ActorID is always the real person
+// behind the request, SubjectID is set when the actor impersonated another user.
+type AuditEntry struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	SubjectID  *uuid.UUID
+	ResourceID *uuid.UUID
+	Method     string
+	Route      string
+	Path       string
+	StatusCode int
+	IPAddress  string
+	CreatedAt  time.Time
+}
+
+func NewAuditEntry(actorID uuid.UUID, subjectID, resourceID *uuid.UUID, method, route, path string, statusCode int, ipAddress string) AuditEntry {
+	return AuditEntry{
+		ID:         uuid.New(),
+		ActorID:    actorID,
+		SubjectID:  subjectID,
+		ResourceID: resourceID,
+		Method:     method,
+		Route:      route,
+		Path:       path,
+		StatusCode: statusCode,
+		IPAddress:  ipAddress,
+		CreatedAt:  time.Now(),
+	}
+}
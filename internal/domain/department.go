@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Department is a unit of the organization. Users belong to it by its name.
+type Department struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+func NewDepartment(name string) Department {
+	return Department{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (d *Department) Rename(name string) bool {
+	if d.Name == name {
+		return false
+	}
+
+	d.Name = name
+	return true
+}
@@ -15,6 +15,9 @@ const (
 	StatusRejected FormStatus = "rejected"
 )
 
+// ErasedFormTitle replaces the title of the forms of an erased user
+const ErasedFormTitle = "[erased]"
+
 type Form struct {
 	ID          uuid.UUID
 	UserID      uuid.UUID
@@ -29,6 +32,9 @@ type Form struct {
 	ReviewedAt *time.Time
 	Status     FormStatus
 	Comment    *string
+
+	// Version is incremented on every update and is used for optimistic locking
+	Version int
 }
 
 func NewForm(userID, executorID uuid.UUID, title, description string, startDate, endDate *time.Time) Form {
@@ -43,6 +49,7 @@ func NewForm(userID, executorID uuid.UUID, title, description string, startDate,
 		CreatedAt:   time.Now(),
 		ReviewedAt:  nil,
 		Status:      StatusPending,
+		Version:     1,
 	}
 }
 
@@ -87,3 +94,13 @@ func (f *Form) RejectForm(comment string) (bool, error) {
 
 	return true, nil
 }
+
+// Reassign hands a pending form over to another HR.
+func (f *Form) Reassign(executorID uuid.UUID) bool {
+	if f.Status != StatusPending || f.ExecutorID == executorID {
+		return false
+	}
+
+	f.ExecutorID = executorID
+	return true
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey remembers a mutating request and its response, so a retry
+// with the same key gets the original response instead of repeating the action.
+type IdempotencyKey struct {
+	UserID      uuid.UUID
+	Key         string
+	Method      string
+	Path        string
+	Fingerprint string
+
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   time.Time
+}
+
+func NewIdempotencyKey(userID uuid.UUID, key, method, path, fingerprint string, ttl time.Duration) IdempotencyKey {
+	now := time.Now()
+
+	return IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// IsCompleted reports whether the original request has finished and its response is stored.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.CompletedAt != nil
+}
+
+func (k *IdempotencyKey) Complete(statusCode int, contentType string, body []byte) {
+	now := time.Now()
+
+	k.StatusCode = statusCode
+	k.ContentType = contentType
+	k.ResponseBody = body
+	k.CompletedAt = &now
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity links a user to an account at an external OpenID Connect provider.
+type Identity struct {
+	Issuer    string
+	Subject   string
+	UserID    uuid.UUID
+	Email     string
+	CreatedAt time.Time
+}
+
+func NewIdentity(issuer, subject string, userID uuid.UUID, email string) Identity {
+	return Identity{
+		Issuer:    issuer,
+		Subject:   subject,
+		UserID:    userID,
+		Email:     email,
+		CreatedAt: time.Now(),
+	}
+}
+
+// AuthState is a pending authorization-code request waiting for the provider callback.
+type AuthState struct {
+	State        string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+	errs "github.com/platonso/hrmate/internal/errors"
+)
+
+// LegalHolds are the users whose data must be kept, e.g. for litigation or an audit.
+// Their accounts can be neither erased nor deleted.
+type LegalHolds []uuid.UUID
+
+// Check returns errs.ErrLegalHold when the user is under legal hold.
+func (h LegalHolds) Check(userID uuid.UUID) error {
+	if slices.Contains(h, userID) {
+		return errs.ErrLegalHold
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimitGroup names a group of routes sharing a limit.
+type RateLimitGroup string
+
+const (
+	RateLimitGroupAuth     RateLimitGroup = "auth"
+	RateLimitGroupEmployee RateLimitGroup = "employee"
+	RateLimitGroupHR       RateLimitGroup = "hr"
+	RateLimitGroupAdmin    RateLimitGroup = "admin"
+)
+
+// RateLimitGroupOf returns the group of the routes a user with the role works with,
+// for endpoints shared by all roles.
+func RateLimitGroupOf(role Role) (RateLimitGroup, bool) {
+	switch role {
+	case RoleEmployee:
+		return RateLimitGroupEmployee, true
+	case RoleHR:
+		return RateLimitGroupHR, true
+	case RoleAdmin:
+		return RateLimitGroupAdmin, true
+	default:
+		return "", false
+	}
+}
+
+// RateLimit allows Requests per Period on average, with bursts of up to Requests.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Enabled reports whether the limit restricts anything; a zero limit means unlimited.
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// refillRate is the number of tokens added per second.
+func (l RateLimit) refillRate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// TokenBucket is the state of a rate-limited client. A request takes a token,
+// tokens are refilled continuously up to the burst size.
+type TokenBucket struct {
+	Key       string
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewTokenBucket creates a full bucket.
+func NewTokenBucket(key string, limit RateLimit, now time.Time) TokenBucket {
+	return TokenBucket{
+		Key:       key,
+		Tokens:    float64(limit.Requests),
+		UpdatedAt: now,
+	}
+}
+
+// RateLimitDecision is the outcome of a request against the bucket.
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed, zero when allowed
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket up to now and takes a token if there is one.
+func (b *TokenBucket) Take(limit RateLimit, now time.Time) RateLimitDecision {
+	rate := limit.refillRate()
+	capacity := float64(limit.Requests)
+
+	if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(capacity, b.Tokens+elapsed*rate)
+	}
+	// The limit may have been lowered since the bucket was stored
+	b.Tokens = math.Min(capacity, b.Tokens)
+	b.UpdatedAt = now
+
+	decision := RateLimitDecision{Limit: limit.Requests}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.Tokens) / rate)
+	}
+
+	decision.Remaining = int(math.Floor(b.Tokens))
+	decision.Reset = secondsToDuration((capacity - b.Tokens) / rate)
+	return decision
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sessionTouchInterval limits how often the last activity of a session is persisted.
+const sessionTouchInterval = time.Minute
+
+// ClientInfo describes the client a session was started from.
+type ClientInfo struct {
+	Device    string
+	IPAddress string
+	UserAgent string
+}
+
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Device     string
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+
+	// ImpersonatorID is the admin acting as UserID in an impersonation session
+	ImpersonatorID *uuid.UUID
+}
+
+func NewSession(userID uuid.UUID, client ClientInfo, ttl time.Duration) Session {
+	now := time.Now()
+	return Session{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Device:     client.Device,
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+}
+
+func NewImpersonationSession(impersonatorID, userID uuid.UUID, client ClientInfo, ttl time.Duration) Session {
+	session := NewSession(userID, client, ttl)
+	session.ImpersonatorID = &impersonatorID
+
+	return session
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Touch records activity and reports whether it is worth persisting.
+func (s *Session) Touch(now time.Time, ipAddress string) bool {
+	if now.Sub(s.LastSeenAt) < sessionTouchInterval && s.IPAddress == ipAddress {
+		return false
+	}
+
+	s.LastSeenAt = now
+	if ipAddress != "" {
+		s.IPAddress = ipAddress
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Scope string
+
+const (
+	ScopeFormsRead   Scope = "forms:read"
+	ScopeFormsWrite  Scope = "forms:write"
+	ScopeFormsReview Scope = "forms:review"
+	ScopeUsersRead   Scope = "users:read"
+	ScopeUsersWrite  Scope = "users:write"
+	// ScopeSCIM lets an identity provider provision users and departments through SCIM
+	ScopeSCIM Scope = "scim"
+)
+
+var roleScopes = map[Role][]Scope{
+	RoleEmployee: {ScopeFormsRead, ScopeFormsWrite},
+	RoleHR:       {ScopeFormsRead, ScopeFormsReview, ScopeUsersRead},
+	RoleAdmin:    {ScopeUsersRead, ScopeUsersWrite, ScopeSCIM},
+}
+
+// ScopeAllowed reports whether a token owned by a user with the role may carry the scope.
+func ScopeAllowed(role Role, scope Scope) bool {
+	for _, s := range roleScopes[role] {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken is a personal access token. Only a hash of the secret is stored.
+type APIToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Prefix     string
+	TokenHash  string
+	Scopes     []Scope
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func NewAPIToken(userID uuid.UUID, name, prefix, tokenHash string, scopes []Scope, expiresAt time.Time) APIToken {
+	return APIToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		TokenHash: tokenHash,
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (t *APIToken) IsUsable(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+func (t *APIToken) HasScope(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *APIToken) Revoke() bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+
+	revokeTime := time.Now()
+	t.RevokedAt = &revokeTime
+	return true
+}
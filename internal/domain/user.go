@@ -21,6 +21,17 @@ type User struct {
 	Email          string
 	HashedPassword string
 	IsActive       bool
+
+	IsServiceAccount bool
+	// Locale is the preferred language of the user, empty when not chosen
+	Locale string
+	// Department the user belongs to, empty when not assigned
+	Department string
+	// ManagerID is the line manager of the user, nil when not assigned
+	ManagerID *uuid.UUID
+
+	// Version is incremented on every update and is used for optimistic locking
+	Version int
 }
 
 func NewUser(role Role, firstName, lastName, position, email, password string) User {
@@ -33,6 +44,7 @@ func NewUser(role Role, firstName, lastName, position, email, password string) U
 		Email:          email,
 		HashedPassword: password,
 		IsActive:       false,
+		Version:        1,
 	}
 
 	if user.Role == RoleEmployee {
@@ -42,11 +54,78 @@ func NewUser(role Role, firstName, lastName, position, email, password string) U
 	return user
 }
 
+// NewServiceAccount creates a non-interactive user that authenticates with API tokens only.
+func NewServiceAccount(role Role, name, email string) User {
+	user := NewUser(role, name, "", "Service account", email, "")
+	user.IsServiceAccount = true
+	user.IsActive = true
+
+	return user
+}
+
 func (u *User) ChangeNames(newFirstName, newLastName string) {
 	u.FirstName = newFirstName
 	u.LastName = newLastName
 }
 
+// ChangeProfile replaces the personal details of the user and reports whether any of them changed.
+func (u *User) ChangeProfile(firstName, lastName, position, email, department string) bool {
+	if u.FirstName == firstName && u.LastName == lastName && u.Position == position &&
+		u.Email == email && u.Department == department {
+		return false
+	}
+
+	u.FirstName = firstName
+	u.LastName = lastName
+	u.Position = position
+	u.Email = email
+	u.Department = department
+	return true
+}
+
+func (u *User) ChangeRole(role Role) bool {
+	if u.Role == role {
+		return false
+	}
+
+	u.Role = role
+	return true
+}
+
+// ChangeManager sets the line manager of the user, nil removes it.
+func (u *User) ChangeManager(managerID *uuid.UUID) bool {
+	if u.ManagerID == nil && managerID == nil ||
+		u.ManagerID != nil && managerID != nil && *u.ManagerID == *managerID {
+		return false
+	}
+
+	u.ManagerID = managerID
+	return true
+}
+
+func (u *User) ChangeDepartment(department string) bool {
+	if u.Department == department {
+		return false
+	}
+
+	u.Department = department
+	return true
+}
+
+// IsActiveAdmin reports whether the user is an administrator able to sign in.
+func (u *User) IsActiveAdmin() bool {
+	return u.Role == RoleAdmin && u.IsActive && !u.IsServiceAccount
+}
+
+func (u *User) ChangeLocale(locale string) bool {
+	if u.Locale == locale {
+		return false
+	}
+
+	u.Locale = locale
+	return true
+}
+
 func (u *User) Activate() bool {
 	if u.IsActive {
 		return false
@@ -64,3 +143,18 @@ func (u *User) Deactivate() bool {
 	u.IsActive = false
 	return true
 }
+
+// Erase removes the personal data of the user. The account is kept, deactivated,
+// so that the forms referencing it stay intact. The email is replaced with an
+// address on the reserved .invalid domain, which can neither sign in nor collide.
+func (u *User) Erase() {
+	u.FirstName = "Deleted"
+	u.LastName = "user"
+	u.Position = ""
+	u.Email = "erased-" + u.ID.String() + "@hrmate.invalid"
+	u.HashedPassword = ""
+	u.IsActive = false
+	u.Locale = ""
+	u.Department = ""
+	u.ManagerID = nil
+}
@@ -7,10 +7,40 @@ var (
 	ErrForbidden          = errors.New("FORBIDDEN")
 	ErrUnauthorized       = errors.New("UNAUTHORIZED")
 	ErrInvalidCredentials = errors.New("INVALID_CREDENTIALS")
+	ErrEmailNotVerified   = errors.New("EMAIL_NOT_VERIFIED")
+	ErrInvalidAuthState   = errors.New("INVALID_AUTH_STATE")
+	ErrIdentityNotFound   = errors.New("IDENTITY_NOT_FOUND")
+	ErrInsufficientScope  = errors.New("INSUFFICIENT_SCOPE")
+
+	ErrImpersonationReadOnly = errors.New("IMPERSONATION_READ_ONLY")
+
+	// Password policy errors
+	ErrPasswordTooShort     = errors.New("PASSWORD_TOO_SHORT")
+	ErrPasswordBreached     = errors.New("PASSWORD_BREACHED")
+	ErrPasswordMatchesEmail = errors.New("PASSWORD_MATCHES_EMAIL")
+
+	// API token errors
+	ErrTokenNotFound = errors.New("TOKEN_NOT_FOUND")
+
+	// Session errors
+	ErrSessionNotFound = errors.New("SESSION_NOT_FOUND")
 
 	// Request errors
 	ErrInvalidRequest = errors.New("INVALID_REQUEST")
-	ErrInternalServer = errors.New("INTERNAL_ERROR")
+	ErrBodyTooLarge   = errors.New("REQUEST_TOO_LARGE")
+	// ErrQueryTooComplex is returned for GraphQL queries over the complexity limit
+	ErrQueryTooComplex = errors.New("QUERY_TOO_COMPLEX")
+
+	// ErrTooManyRequests is returned when the client has exceeded its rate limit
+	ErrTooManyRequests = errors.New("TOO_MANY_REQUESTS")
+	ErrInternalServer  = errors.New("INTERNAL_ERROR")
+
+	// ErrPreconditionFailed is returned when the resource has changed since the client read it
+	ErrPreconditionFailed = errors.New("PRECONDITION_FAILED")
+
+	// Idempotency errors
+	ErrIdempotencyKeyInUse    = errors.New("IDEMPOTENCY_KEY_IN_USE")
+	ErrIdempotencyKeyMismatch = errors.New("IDEMPOTENCY_KEY_MISMATCH")
 
 	// Form errors
 	ErrFormNotFound        = errors.New("FORM_NOT_FOUND")
@@ -24,4 +54,16 @@ var (
 	ErrUserNotFound      = errors.New("USER_NOT_FOUND")
 	ErrUserNotActive     = errors.New("USER_NOT_ACTIVE")
 	ErrUserAlreadyExists = errors.New("USER_ALREADY_EXISTS")
+	// ErrLastAdmin is returned when a change would leave no active administrator
+	ErrLastAdmin = errors.New("LAST_ADMIN")
+	// ErrUserHasForms is returned when deleting a user that authored or reviewed forms
+	ErrUserHasForms = errors.New("USER_HAS_FORMS")
+	// ErrLegalHold is returned when erasing or deleting a user whose data must be kept
+	ErrLegalHold = errors.New("LEGAL_HOLD")
+	// ErrInvitationsUnavailable is returned when users are invited but no mail server is configured
+	ErrInvitationsUnavailable = errors.New("INVITATIONS_UNAVAILABLE")
+
+	// Department errors
+	ErrDepartmentNotFound      = errors.New("DEPARTMENT_NOT_FOUND")
+	ErrDepartmentAlreadyExists = errors.New("DEPARTMENT_ALREADY_EXISTS")
 )
@@ -0,0 +1,27 @@
+package errors
+
+// FieldError describes a single invalid field of a request.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "scopes[0]". Empty for the body as a whole.
+	Field   string
+	Rule    string
+	Param   string
+	Message string
+}
+
+// ValidationError is an ErrInvalidRequest carrying field-level details.
+type ValidationError struct {
+	Details []FieldError
+}
+
+func NewValidationError(details ...FieldError) *ValidationError {
+	return &ValidationError{Details: details}
+}
+
+func (e *ValidationError) Error() string {
+	return ErrInvalidRequest.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidRequest
+}
@@ -0,0 +1,94 @@
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// auditMethod takes the place of the HTTP method in audit entries of RPCs,
+// whose route and path are the full method name.
+const auditMethod = "GRPC"
+
+type AuditService interface {
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+}
+
+type auditInterceptor struct {
+	auditSvc AuditService
+}
+
+// intercept writes an audit entry for every write call, like the audit middleware
+// of the REST API. It must run after authInterceptor.
+func (a *auditInterceptor) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+
+	if !policies[info.FullMethod].write {
+		return resp, err
+	}
+
+	p, ok := principalFrom(ctx)
+	if !ok {
+		return resp, err
+	}
+
+	actorID := p.userID
+	var subjectID *uuid.UUID
+	if p.actorID != nil {
+		actorID = *p.actorID
+		subjectID = &p.userID
+	}
+
+	var resourceID *uuid.UUID
+	if withID, ok := req.(interface{ GetId() string }); ok {
+		if id, err := uuid.Parse(withID.GetId()); err == nil {
+			resourceID = &id
+		}
+	}
+
+	entry := domain.NewAuditEntry(
+		actorID,
+		subjectID,
+		resourceID,
+		auditMethod,
+		info.FullMethod,
+		info.FullMethod,
+		httpStatus(status.Code(err)),
+		clientIP(ctx),
+	)
+
+	// Errors are logged by the service, the call has been served either way
+	_ = a.auditSvc.Record(context.WithoutCancel(ctx), &entry)
+
+	return resp, err
+}
+
+// httpStatus maps a gRPC code to the HTTP status the REST API answers with,
+// so audit entries of both transports can be filtered the same way.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Aborted:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
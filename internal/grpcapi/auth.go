@@ -0,0 +1,223 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	hrmatev1 "github.com/platonso/hrmate/api/hrmate/v1"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	authmodel "github.com/platonso/hrmate/internal/service/auth/model"
+	"github.com/platonso/hrmate/internal/service/token"
+	tokenmodel "github.com/platonso/hrmate/internal/service/token/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type AuthService interface {
+	ParseAccessToken(tokenString string) (*authmodel.AccessClaims, error)
+}
+
+type SessionService interface {
+	Validate(ctx context.Context, sessionID, userID uuid.UUID, ipAddress string) error
+}
+
+type TokenService interface {
+	Authenticate(ctx context.Context, rawToken string) (*tokenmodel.Principal, error)
+}
+
+type RateLimitService interface {
+	Allow(ctx context.Context, group domain.RateLimitGroup, subject string) (*domain.RateLimitDecision, error)
+}
+
+// policy is what the REST router expresses with RequireRoles and RequireScopes.
+type policy struct {
+	roles []domain.Role
+	// scope is required from API tokens only, interactive sessions are not limited by scopes
+	scope domain.Scope
+	// write calls are refused to impersonation sessions unless explicitly allowed
+	write bool
+}
+
+// policies lists every RPC; a method missing here is refused, so new RPCs cannot go out unprotected.
+var policies = map[string]policy{
+	hrmatev1.FormService_CreateForm_FullMethodName:  {roles: []domain.Role{domain.RoleEmployee}, scope: domain.ScopeFormsWrite, write: true},
+	hrmatev1.FormService_GetForm_FullMethodName:     {roles: []domain.Role{domain.RoleEmployee, domain.RoleHR}, scope: domain.ScopeFormsRead},
+	hrmatev1.FormService_ListForms_FullMethodName:   {roles: []domain.Role{domain.RoleEmployee, domain.RoleHR}, scope: domain.ScopeFormsRead},
+	hrmatev1.FormService_ApproveForm_FullMethodName: {roles: []domain.Role{domain.RoleHR}, scope: domain.ScopeFormsReview, write: true},
+	hrmatev1.FormService_RejectForm_FullMethodName:  {roles: []domain.Role{domain.RoleHR}, scope: domain.ScopeFormsReview, write: true},
+
+	hrmatev1.UserService_GetUser_FullMethodName:        {roles: []domain.Role{domain.RoleEmployee, domain.RoleHR, domain.RoleAdmin}, scope: domain.ScopeUsersRead},
+	hrmatev1.UserService_ListUsers_FullMethodName:      {roles: []domain.Role{domain.RoleHR, domain.RoleAdmin}, scope: domain.ScopeUsersRead},
+	hrmatev1.UserService_ActivateUser_FullMethodName:   {roles: []domain.Role{domain.RoleAdmin}, scope: domain.ScopeUsersWrite, write: true},
+	hrmatev1.UserService_DeactivateUser_FullMethodName: {roles: []domain.Role{domain.RoleAdmin}, scope: domain.ScopeUsersWrite, write: true},
+}
+
+type principalKey struct{}
+
+// principal is the authenticated caller of an RPC.
+type principal struct {
+	userID uuid.UUID
+	role   domain.Role
+	// scopes is nil for interactive sessions
+	scopes []domain.Scope
+	// actorID is the administrator behind an impersonation session
+	actorID *uuid.UUID
+}
+
+func principalFrom(ctx context.Context) (*principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*principal)
+	return p, ok
+}
+
+type authInterceptor struct {
+	authSvc      AuthService
+	userSvc      UserService
+	tokenSvc     TokenService
+	sessionSvc   SessionService
+	rateLimitSvc RateLimitService
+
+	allowImpersonatedWrites bool
+}
+
+// intercept accepts the same bearer credentials as the REST API in the authorization
+// metadata: an access token of an interactive session or an API token.
+func (a *authInterceptor) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	pol, ok := policies[info.FullMethod]
+	if !ok {
+		return nil, toStatus(ctx, errs.ErrForbidden, "method is not available")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	authHeader := first(md, "authorization")
+	if authHeader == "" {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "authorization metadata is required")
+	}
+
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "bearer token is required")
+	}
+
+	var p *principal
+	var err error
+	if strings.HasPrefix(tokenString, token.Prefix) {
+		p, err = a.authenticateAPIToken(ctx, tokenString)
+	} else {
+		p, err = a.authenticateSession(ctx, tokenString)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = withLogUser(ctx, p.userID, p.actorID)
+	if p.actorID != nil {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(
+			"x-impersonated-by", p.actorID.String(),
+			"x-impersonated-user", p.userID.String(),
+		))
+
+		if pol.write && !a.allowImpersonatedWrites {
+			return nil, toStatus(ctx, errs.ErrImpersonationReadOnly, "changes are not allowed while impersonating")
+		}
+	}
+
+	if err := a.limit(ctx, p); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(pol.roles, p.role) {
+		return nil, toStatus(ctx, errs.ErrForbidden, "forbidden")
+	}
+
+	if p.scopes != nil && !slices.Contains(p.scopes, pol.scope) {
+		return nil, toStatus(ctx, errs.ErrInsufficientScope, "token lacks required scope: "+string(pol.scope))
+	}
+
+	if err := a.requireActiveStatus(ctx, p.userID); err != nil {
+		return nil, err
+	}
+
+	return handler(context.WithValue(ctx, principalKey{}, p), req)
+}
+
+func (a *authInterceptor) authenticateSession(ctx context.Context, tokenString string) (*principal, error) {
+	claims, err := a.authSvc.ParseAccessToken(tokenString)
+	if err != nil {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "invalid token")
+	}
+
+	if err := a.sessionSvc.Validate(ctx, claims.SessionID, claims.UserID, clientIP(ctx)); err != nil {
+		if errors.Is(err, errs.ErrUnauthorized) {
+			return nil, toStatus(ctx, errs.ErrUnauthorized, "session has expired or was revoked")
+		}
+		return nil, toStatus(ctx, errs.ErrInternalServer, "failed to verify session")
+	}
+
+	return &principal{
+		userID:  claims.UserID,
+		role:    claims.Role,
+		actorID: claims.ActorID,
+	}, nil
+}
+
+func (a *authInterceptor) authenticateAPIToken(ctx context.Context, rawToken string) (*principal, error) {
+	tokenPrincipal, err := a.tokenSvc.Authenticate(ctx, rawToken)
+	if err != nil {
+		if errors.Is(err, errs.ErrUnauthorized) {
+			return nil, toStatus(ctx, errs.ErrUnauthorized, "invalid token")
+		}
+		return nil, toStatus(ctx, errs.ErrInternalServer, "failed to verify token")
+	}
+
+	scopes := tokenPrincipal.Scopes
+	if scopes == nil {
+		scopes = []domain.Scope{}
+	}
+
+	return &principal{
+		userID: tokenPrincipal.UserID,
+		role:   tokenPrincipal.Role,
+		scopes: scopes,
+	}, nil
+}
+
+// limit counts the call against the REST limit of the caller's role and, like it, fails open.
+func (a *authInterceptor) limit(ctx context.Context, p *principal) error {
+	group, ok := domain.RateLimitGroupOf(p.role)
+	if !ok {
+		return nil
+	}
+
+	decision, err := a.rateLimitSvc.Allow(ctx, group, "user:"+p.userID.String())
+	if err != nil || decision == nil || decision.Allowed {
+		return nil
+	}
+
+	retryAfter := strconv.FormatInt(int64(math.Ceil(decision.RetryAfter.Seconds())), 10)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+
+	return toStatus(ctx, errs.ErrTooManyRequests, "too many requests")
+}
+
+func (a *authInterceptor) requireActiveStatus(ctx context.Context, userID uuid.UUID) error {
+	user, err := a.userSvc.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return toStatus(ctx, errs.ErrUnauthorized, "authentication required")
+		}
+		logger.FromContext(ctx).Error("failed to check active status", "error", err)
+		return toStatus(ctx, errs.ErrInternalServer, "failed to verify account status")
+	}
+
+	if !user.IsActive {
+		return toStatus(ctx, errs.ErrForbidden, "account is not active")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package grpcapi
+
+import (
+	"time"
+
+	hrmatev1 "github.com/platonso/hrmate/api/hrmate/v1"
+	"github.com/platonso/hrmate/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var formStatuses = map[domain.FormStatus]hrmatev1.FormStatus{
+	domain.StatusPending:  hrmatev1.FormStatus_FORM_STATUS_PENDING,
+	domain.StatusApproved: hrmatev1.FormStatus_FORM_STATUS_APPROVED,
+	domain.StatusRejected: hrmatev1.FormStatus_FORM_STATUS_REJECTED,
+}
+
+var roles = map[domain.Role]hrmatev1.Role{
+	domain.RoleEmployee: hrmatev1.Role_ROLE_EMPLOYEE,
+	domain.RoleHR:       hrmatev1.Role_ROLE_HR,
+	domain.RoleAdmin:    hrmatev1.Role_ROLE_ADMIN,
+}
+
+func toFormMessage(form *domain.Form) *hrmatev1.Form {
+	return &hrmatev1.Form{
+		Id:          form.ID.String(),
+		UserId:      form.UserID.String(),
+		Title:       form.Title,
+		Description: form.Description,
+		StartDate:   toTimestamp(form.StartDate),
+		EndDate:     toTimestamp(form.EndDate),
+		CreatedAt:   timestamppb.New(form.CreatedAt),
+		ReviewedAt:  toTimestamp(form.ReviewedAt),
+		Status:      formStatuses[form.Status],
+		Comment:     form.Comment,
+		Version:     int32(form.Version),
+	}
+}
+
+func toFormMessages(forms []domain.Form) []*hrmatev1.Form {
+	result := make([]*hrmatev1.Form, 0, len(forms))
+	for i := range forms {
+		result = append(result, toFormMessage(&forms[i]))
+	}
+	return result
+}
+
+func toUserMessage(user *domain.User) *hrmatev1.User {
+	return &hrmatev1.User{
+		Id:        user.ID.String(),
+		Role:      roles[user.Role],
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Position:  user.Position,
+		Email:     user.Email,
+		IsActive:  user.IsActive,
+		Version:   int32(user.Version),
+	}
+}
+
+// fromFormStatus maps the proto enum back, ok is false for FORM_STATUS_UNSPECIFIED.
+func fromFormStatus(status hrmatev1.FormStatus) (domain.FormStatus, bool) {
+	for formStatus, value := range formStatuses {
+		if value == status {
+			return formStatus, true
+		}
+	}
+	return "", false
+}
+
+// fromRole maps the proto enum back, ok is false for ROLE_UNSPECIFIED.
+func fromRole(role hrmatev1.Role) (domain.Role, bool) {
+	for domainRole, value := range roles {
+		if value == role {
+			return domainRole, true
+		}
+	}
+	return "", false
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func expectedVersion(version *int32) *int {
+	if version == nil {
+		return nil
+	}
+	v := int(*version)
+	return &v
+}
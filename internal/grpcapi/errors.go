@@ -0,0 +1,103 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies the error codes of this application in ErrorInfo
+const errorDomain = "hrmate"
+
+// toStatus converts a service error into a gRPC status. The error code of the REST
+// API travels as the ErrorInfo reason, so clients can branch on the same codes.
+func toStatus(ctx context.Context, err error, msg string) error {
+	if err == nil {
+		err = errs.ErrInternalServer
+		msg = "unknown error"
+	}
+
+	st := status.New(statusCode(err), msg)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   err.Error(),
+		Domain:   errorDomain,
+		Metadata: map[string]string{"request_id": logger.RequestID(ctx)},
+	}}
+
+	var validationErr *errs.ValidationError
+	if errors.As(err, &validationErr) {
+		badRequest := &errdetails.BadRequest{}
+		for _, d := range validationErr.Details {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       d.Field,
+				Description: d.Message,
+				Reason:      d.Rule,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	// Details only fail to marshal on a programming error, the status is still usable then
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+func statusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, errs.ErrInvalidCredentials),
+		errors.Is(err, errs.ErrUnauthorized),
+		errors.Is(err, errs.ErrInvalidAuthState):
+		return codes.Unauthenticated
+
+	case errors.Is(err, errs.ErrUserNotActive),
+		errors.Is(err, errs.ErrForbidden),
+		errors.Is(err, errs.ErrEmailNotVerified),
+		errors.Is(err, errs.ErrInsufficientScope),
+		errors.Is(err, errs.ErrImpersonationReadOnly):
+		return codes.PermissionDenied
+
+	case errors.Is(err, errs.ErrUserAlreadyExists):
+		return codes.AlreadyExists
+
+	// The resource is not in a state where the call makes sense
+	case errors.Is(err, errs.ErrFormAlreadyApproved),
+		errors.Is(err, errs.ErrFormAlreadyRejected),
+		errors.Is(err, errs.ErrNoAvailableExecutors),
+		errors.Is(err, errs.ErrLastAdmin),
+		errors.Is(err, errs.ErrUserHasForms):
+		return codes.FailedPrecondition
+
+	case errors.Is(err, errs.ErrUserNotFound),
+		errors.Is(err, errs.ErrFormNotFound),
+		errors.Is(err, errs.ErrIdentityNotFound),
+		errors.Is(err, errs.ErrTokenNotFound),
+		errors.Is(err, errs.ErrSessionNotFound):
+		return codes.NotFound
+
+	case errors.Is(err, errs.ErrInvalidRequest),
+		errors.Is(err, errs.ErrPasswordTooShort),
+		errors.Is(err, errs.ErrPasswordBreached),
+		errors.Is(err, errs.ErrPasswordMatchesEmail):
+		return codes.InvalidArgument
+
+	// A concurrent change, the client should read the resource again and retry
+	case errors.Is(err, errs.ErrPreconditionFailed):
+		return codes.Aborted
+
+	case errors.Is(err, errs.ErrTooManyRequests):
+		return codes.ResourceExhausted
+
+	default:
+		return codes.Internal
+	}
+}
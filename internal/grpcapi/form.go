@@ -0,0 +1,145 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	hrmatev1 "github.com/platonso/hrmate/api/hrmate/v1"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/i18n"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+	"github.com/platonso/hrmate/internal/service/form/model"
+)
+
+type FormService interface {
+	Create(ctx context.Context, formInput *model.FormCreateInput, userID uuid.UUID) (*domain.Form, error)
+	GetForm(ctx context.Context, formID uuid.UUID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.Form, error)
+	GetForms(ctx context.Context, filter *formservice.Filter, requesterID uuid.UUID, requesterRole domain.Role) ([]domain.Form, error)
+	Approve(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error)
+	Reject(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error)
+}
+
+type formServer struct {
+	hrmatev1.UnimplementedFormServiceServer
+
+	svc FormService
+}
+
+func (s *formServer) CreateForm(ctx context.Context, req *hrmatev1.CreateFormRequest) (*hrmatev1.CreateFormResponse, error) {
+	p, ok := principalFrom(ctx)
+	if !ok {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "authentication required")
+	}
+
+	if req.GetTitle() == "" {
+		return nil, toStatus(ctx, fieldError(ctx, "title", "required"), "invalid request format")
+	}
+
+	form, err := s.svc.Create(ctx, &model.FormCreateInput{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		StartDate:   fromTimestamp(req.GetStartDate()),
+		EndDate:     fromTimestamp(req.GetEndDate()),
+	}, p.userID)
+	if err != nil {
+		return nil, toStatus(ctx, err, "failed to create form")
+	}
+
+	return &hrmatev1.CreateFormResponse{Form: toFormMessage(form)}, nil
+}
+
+func (s *formServer) GetForm(ctx context.Context, req *hrmatev1.GetFormRequest) (*hrmatev1.GetFormResponse, error) {
+	p, ok := principalFrom(ctx)
+	if !ok {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "authentication required")
+	}
+
+	formID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid form id format")
+	}
+
+	form, err := s.svc.GetForm(ctx, formID, p.userID, p.role)
+	if err != nil {
+		return nil, toStatus(ctx, err, "failed to get form")
+	}
+
+	return &hrmatev1.GetFormResponse{Form: toFormMessage(form)}, nil
+}
+
+func (s *formServer) ListForms(ctx context.Context, req *hrmatev1.ListFormsRequest) (*hrmatev1.ListFormsResponse, error) {
+	p, ok := principalFrom(ctx)
+	if !ok {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "authentication required")
+	}
+
+	filter := &formservice.Filter{}
+
+	if req.GetUserId() != "" {
+		userID, err := uuid.Parse(req.GetUserId())
+		if err != nil {
+			return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid user_id format")
+		}
+		filter.UserID = &userID
+	}
+
+	if req.GetStatus() != hrmatev1.FormStatus_FORM_STATUS_UNSPECIFIED {
+		status, ok := fromFormStatus(req.GetStatus())
+		if !ok {
+			return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid status")
+		}
+		filter.FormStatus = &status
+	}
+
+	forms, err := s.svc.GetForms(ctx, filter, p.userID, p.role)
+	if err != nil {
+		return nil, toStatus(ctx, err, "failed to get forms")
+	}
+
+	return &hrmatev1.ListFormsResponse{Forms: toFormMessages(forms)}, nil
+}
+
+func (s *formServer) ApproveForm(ctx context.Context, req *hrmatev1.ApproveFormRequest) (*hrmatev1.ApproveFormResponse, error) {
+	form, err := s.review(ctx, s.svc.Approve, req.GetId(), req.GetComment(), req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &hrmatev1.ApproveFormResponse{Form: toFormMessage(form)}, nil
+}
+
+func (s *formServer) RejectForm(ctx context.Context, req *hrmatev1.RejectFormRequest) (*hrmatev1.RejectFormResponse, error) {
+	form, err := s.review(ctx, s.svc.Reject, req.GetId(), req.GetComment(), req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &hrmatev1.RejectFormResponse{Form: toFormMessage(form)}, nil
+}
+
+func (s *formServer) review(
+	ctx context.Context,
+	action func(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error),
+	id, comment string,
+	version *int32,
+) (*domain.Form, error) {
+	formID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid form id format")
+	}
+
+	form, err := action(ctx, formID, comment, expectedVersion(version))
+	if err != nil {
+		return nil, toStatus(ctx, err, "failed to process form action")
+	}
+
+	return form, nil
+}
+
+// fieldError builds a validation error worded like the ones of the REST API.
+func fieldError(ctx context.Context, field, rule string) error {
+	return errs.NewValidationError(errs.FieldError{
+		Field:   field,
+		Rule:    rule,
+		Message: i18n.T(i18n.FromContext(ctx), "validation."+rule),
+	})
+}
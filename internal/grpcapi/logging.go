@@ -0,0 +1,124 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/i18n"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey is the metadata counterpart of the X-Request-ID header
+const requestIDKey = "x-request-id"
+
+type callLogKey struct{}
+
+// callLog collects the fields that become known only in the auth interceptor.
+type callLog struct {
+	userID  *uuid.UUID
+	actorID *uuid.UUID
+}
+
+type accessLog struct {
+	logger *slog.Logger
+}
+
+// intercept sets up the request ID, logger, locale and span of the call and logs
+// it once it has been served, like the HTTP middleware chain does for requests.
+func (a *accessLog) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := first(md, requestIDKey)
+	if !logger.ValidRequestID(requestID) {
+		requestID = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+
+	entry := &callLog{}
+	log := a.logger.With("request_id", requestID)
+
+	ctx = logger.WithRequestID(ctx, requestID)
+	ctx = logger.WithContext(ctx, log)
+	ctx = context.WithValue(ctx, callLogKey{}, entry)
+	ctx = i18n.WithLocale(ctx, i18n.Match(first(md, "accept-language")))
+
+	ctx, span := tracing.StartRPC(ctx, info.FullMethod)
+	defer span.End()
+
+	if sc := span.SpanContext(); sc.IsValid() {
+		ctx = logger.With(ctx, "trace_id", sc.TraceID().String())
+	}
+
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	tracing.FinishRPC(span, code)
+
+	attrs := []slog.Attr{
+		slog.String("method", info.FullMethod),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("client_ip", clientIP(ctx)),
+	}
+	if entry.userID != nil {
+		attrs = append(attrs, slog.String("user_id", entry.userID.String()))
+	}
+	if entry.actorID != nil {
+		attrs = append(attrs, slog.String("actor_id", entry.actorID.String()))
+	}
+
+	level := slog.LevelInfo
+	if code == codes.Internal || code == codes.Unknown {
+		level = slog.LevelError
+	}
+	log.LogAttrs(ctx, level, "rpc served", attrs...)
+
+	return resp, err
+}
+
+// withLogUser attributes the rest of the call to the authenticated user,
+// both in the context logger and in the access log.
+func withLogUser(ctx context.Context, userID uuid.UUID, actorID *uuid.UUID) context.Context {
+	if entry, ok := ctx.Value(callLogKey{}).(*callLog); ok {
+		entry.userID = &userID
+		entry.actorID = actorID
+	}
+
+	if actorID != nil {
+		return logger.With(ctx, "user_id", userID, "actor_id", *actorID)
+	}
+	return logger.With(ctx, "user_id", userID)
+}
+
+// clientIP returns the address of the peer without the port.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+func first(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
@@ -0,0 +1,65 @@
+// Package grpcapi exposes the form and user operations over gRPC. It is another
+// transport next to the REST handlers and calls the same services.
+package grpcapi
+
+import (
+	"crypto/tls"
+	"log/slog"
+
+	hrmatev1 "github.com/platonso/hrmate/api/hrmate/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+)
+
+type Services struct {
+	Auth      AuthService
+	User      UserService
+	Form      FormService
+	Token     TokenService
+	Session   SessionService
+	RateLimit RateLimitService
+	Audit     AuditService
+}
+
+type Options struct {
+	AllowImpersonatedWrites bool
+	// Reflection lets tools like grpcurl discover the services
+	Reflection bool
+	Logger     *slog.Logger
+	// TLSConfig is nil when TLS is terminated in front of the application
+	TLSConfig *tls.Config
+}
+
+func NewServer(svcs Services, opts Options) *grpc.Server {
+	auth := &authInterceptor{
+		authSvc:      svcs.Auth,
+		userSvc:      svcs.User,
+		tokenSvc:     svcs.Token,
+		sessionSvc:   svcs.Session,
+		rateLimitSvc: svcs.RateLimit,
+
+		allowImpersonatedWrites: opts.AllowImpersonatedWrites,
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			(&accessLog{logger: opts.Logger}).intercept,
+			auth.intercept,
+			(&auditInterceptor{auditSvc: svcs.Audit}).intercept,
+		),
+	}
+	if opts.TLSConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(opts.TLSConfig)))
+	}
+
+	srv := grpc.NewServer(serverOpts...)
+	hrmatev1.RegisterFormServiceServer(srv, &formServer{svc: svcs.Form})
+	hrmatev1.RegisterUserServiceServer(srv, &userServer{svc: svcs.User})
+
+	if opts.Reflection {
+		reflection.Register(srv)
+	}
+
+	return srv
+}
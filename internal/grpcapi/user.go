@@ -0,0 +1,128 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	hrmatev1 "github.com/platonso/hrmate/api/hrmate/v1"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	userservice "github.com/platonso/hrmate/internal/service/user"
+)
+
+type UserService interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	GetUser(ctx context.Context, userID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.User, error)
+	SearchUsers(ctx context.Context, filter *userservice.Filter, requesterRole domain.Role) (*userservice.Page, error)
+	ChangeActiveStatus(ctx context.Context, userID uuid.UUID, isActive bool, expectedVersion *int) (*domain.User, error)
+}
+
+type userServer struct {
+	hrmatev1.UnimplementedUserServiceServer
+
+	svc UserService
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *hrmatev1.GetUserRequest) (*hrmatev1.GetUserResponse, error) {
+	p, ok := principalFrom(ctx)
+	if !ok {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "authentication required")
+	}
+
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid user id format")
+	}
+
+	user, err := s.svc.GetUser(ctx, userID, p.userID, p.role)
+	if err != nil {
+		return nil, toStatus(ctx, err, "failed to get user")
+	}
+
+	return &hrmatev1.GetUserResponse{User: toUserMessage(user)}, nil
+}
+
+// ListUsers returns a page of the users visible to the requester, sorted by name and
+// narrowed down by the optional filters, the same page GET /api/v1/admin/users returns.
+func (s *userServer) ListUsers(ctx context.Context, req *hrmatev1.ListUsersRequest) (*hrmatev1.ListUsersResponse, error) {
+	p, ok := principalFrom(ctx)
+	if !ok {
+		return nil, toStatus(ctx, errs.ErrUnauthorized, "authentication required")
+	}
+
+	filter := &userservice.Filter{
+		Search:   req.GetSearch(),
+		IsActive: req.IsActive,
+		Limit:    int(req.GetPageSize()),
+	}
+
+	if req.GetRole() != hrmatev1.Role_ROLE_UNSPECIFIED {
+		role, ok := fromRole(req.GetRole())
+		if !ok {
+			return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid role")
+		}
+		filter.Role = &role
+	}
+
+	if department := req.GetDepartment(); department != "" {
+		filter.Department = &department
+	}
+
+	if req.GetPageSize() < 0 {
+		return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid page size")
+	}
+
+	if token := req.GetPageToken(); token != "" {
+		cursor, err := userservice.ParseCursor(token)
+		if err != nil {
+			return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid page token")
+		}
+		filter.After = cursor
+	}
+
+	page, err := s.svc.SearchUsers(ctx, filter, p.role)
+	if err != nil {
+		return nil, toStatus(ctx, err, "failed to get users")
+	}
+
+	result := make([]*hrmatev1.User, 0, len(page.Users))
+	for i := range page.Users {
+		result = append(result, toUserMessage(&page.Users[i]))
+	}
+
+	resp := &hrmatev1.ListUsersResponse{Users: result}
+	if page.Next != nil {
+		resp.NextPageToken = page.Next.Encode()
+	}
+	return resp, nil
+}
+
+func (s *userServer) ActivateUser(ctx context.Context, req *hrmatev1.ActivateUserRequest) (*hrmatev1.ActivateUserResponse, error) {
+	user, err := s.changeActiveStatus(ctx, req.GetId(), true, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &hrmatev1.ActivateUserResponse{User: toUserMessage(user)}, nil
+}
+
+func (s *userServer) DeactivateUser(ctx context.Context, req *hrmatev1.DeactivateUserRequest) (*hrmatev1.DeactivateUserResponse, error) {
+	user, err := s.changeActiveStatus(ctx, req.GetId(), false, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &hrmatev1.DeactivateUserResponse{User: toUserMessage(user)}, nil
+}
+
+func (s *userServer) changeActiveStatus(ctx context.Context, id string, isActive bool, version *int32) (*domain.User, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, toStatus(ctx, errs.ErrInvalidRequest, "invalid user id format")
+	}
+
+	user, err := s.svc.ChangeActiveStatus(ctx, userID, isActive, expectedVersion(version))
+	if err != nil {
+		return nil, toStatus(ctx, err, "failed to change user status")
+	}
+
+	return user, nil
+}
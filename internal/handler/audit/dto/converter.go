@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToAuditEntryResponse(entry *domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		SubjectID:  entry.SubjectID,
+		ResourceID: entry.ResourceID,
+		Method:     entry.Method,
+		Route:      entry.Route,
+		Path:       entry.Path,
+		StatusCode: entry.StatusCode,
+		IPAddress:  entry.IPAddress,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+func ToAuditEntryResponses(entries []domain.AuditEntry) []AuditEntryResponse {
+	if len(entries) == 0 {
+		return []AuditEntryResponse{}
+	}
+
+	responses := make([]AuditEntryResponse, len(entries))
+	for i := range entries {
+		responses[i] = ToAuditEntryResponse(&entries[i])
+	}
+	return responses
+}
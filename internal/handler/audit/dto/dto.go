@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEntryResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ActorID    uuid.UUID  `json:"actorId"`
+	SubjectID  *uuid.UUID `json:"subjectId"`
+	ResourceID *uuid.UUID `json:"resourceId"`
+	Method     string     `json:"method"`
+	Route      string     `json:"route"`
+	Path       string     `json:"path"`
+	StatusCode int        `json:"statusCode"`
+	IPAddress  string     `json:"ipAddress"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
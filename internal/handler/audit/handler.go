@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/audit/dto"
+	"github.com/platonso/hrmate/internal/handler/response"
+	auditservice "github.com/platonso/hrmate/internal/service/audit"
+)
+
+type Service interface {
+	GetEntries(ctx context.Context, filter *auditservice.Filter) ([]domain.AuditEntry, error)
+}
+
+type Handler struct {
+	svc Service
+}
+
+func NewHandler(svc Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) HandleGetEntries(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid filter parameters")
+		return
+	}
+
+	entries, err := h.svc.GetEntries(r.Context(), filter)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to get audit log")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, dto.ToAuditEntryResponses(entries))
+}
+
+func parseFilter(r *http.Request) (*auditservice.Filter, error) {
+	filter := &auditservice.Filter{}
+	query := r.URL.Query()
+
+	for param, dest := range map[string]**uuid.UUID{
+		"actor_id":   &filter.ActorID,
+		"subject_id": &filter.SubjectID,
+		"user_id":    &filter.InvolvedUserID,
+	} {
+		if value := query.Get(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", param, err)
+			}
+			*dest = &id
+		}
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid limit: %s", limitStr)
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
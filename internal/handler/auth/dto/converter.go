@@ -15,3 +15,11 @@ func ToRegisterInput(req *RegisterRequest) *model.RegisterInput {
 		Role:      domain.Role(req.Role),
 	}
 }
+
+func ToImpersonationResponse(result *model.ImpersonationResult) ImpersonationResponse {
+	return ImpersonationResponse{
+		Token:     result.Token,
+		SubjectID: result.SubjectID,
+		ExpiresAt: result.ExpiresAt,
+	}
+}
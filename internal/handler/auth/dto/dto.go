@@ -1,19 +1,36 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type RegisterRequest struct {
 	FirstName string `json:"firstName" validate:"required,min=2"`
 	LastName  string `json:"lastName" validate:"required,min=2"`
 	Position  string `json:"position" validate:"required,min=2"`
 	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required"`
+	Password  string `json:"password" validate:"required,max=128"`
 	Role      string `json:"role" validate:"required,oneof=employee hr"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Password string `json:"password" validate:"required,max=128"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required,max=128"`
+	NewPassword     string `json:"newPassword" validate:"required,max=128"`
 }
 
 type AuthResponse struct {
 	Token string `json:"token"`
 }
+
+type ImpersonationResponse struct {
+	Token     string    `json:"token"`
+	SubjectID uuid.UUID `json:"subjectId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
 	errs "github.com/platonso/hrmate/internal/errors"
 	"github.com/platonso/hrmate/internal/handler/auth/dto"
+	"github.com/platonso/hrmate/internal/handler/middleware"
 	"github.com/platonso/hrmate/internal/handler/request"
 	"github.com/platonso/hrmate/internal/handler/response"
 	"github.com/platonso/hrmate/internal/service/auth/model"
 )
 
 type Service interface {
-	Register(ctx context.Context, registerInput *model.RegisterInput) (string, error)
-	Login(ctx context.Context, email, password string) (string, error)
+	Register(ctx context.Context, registerInput *model.RegisterInput, client domain.ClientInfo) (string, error)
+	Login(ctx context.Context, email, password string, client domain.ClientInfo) (string, error)
+	Impersonate(ctx context.Context, actorID, subjectID uuid.UUID, client domain.ClientInfo) (*model.ImpersonationResult, error)
+	ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, currentPassword, newPassword string) error
 }
 
 type Handler struct {
@@ -29,13 +35,13 @@ func NewHandler(svc Service) *Handler {
 func (h *Handler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 	var req dto.RegisterRequest
 	if err := request.DecodeAndValidate(r, &req); err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid request format")
+		response.WriteError(w, r, err, "invalid request format")
 		return
 	}
 
-	token, err := h.svc.Register(r.Context(), dto.ToRegisterInput(&req))
+	token, err := h.svc.Register(r.Context(), dto.ToRegisterInput(&req), request.ClientInfo(r))
 	if err != nil {
-		response.WriteError(w, err, "failed to register")
+		response.WriteError(w, r, err, "failed to register")
 		return
 	}
 
@@ -46,15 +52,63 @@ func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	var req dto.LoginRequest
 
 	if err := request.DecodeAndValidate(r, &req); err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid request format")
+		response.WriteError(w, r, err, "invalid request format")
 		return
 	}
 
-	token, err := h.svc.Login(r.Context(), req.Email, req.Password)
+	token, err := h.svc.Login(r.Context(), req.Email, req.Password, request.ClientInfo(r))
 	if err != nil {
-		response.WriteError(w, err, "failed to login")
+		response.WriteError(w, r, err, "failed to login")
 		return
 	}
 
 	response.WriteJSON(w, http.StatusOK, dto.AuthResponse{Token: token})
 }
+
+func (h *Handler) HandleImpersonate(w http.ResponseWriter, r *http.Request) {
+	subjectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	actorID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	result, err := h.svc.Impersonate(r.Context(), actorID, subjectID, request.ClientInfo(r))
+	if err != nil {
+		response.WriteError(w, r, err, "failed to impersonate user")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, dto.ToImpersonationResponse(result))
+}
+
+func (h *Handler) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+	sessionID, ok := middleware.GetSessionID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	if err := h.svc.ChangePassword(r.Context(), userID, sessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		response.WriteError(w, r, err, "failed to change password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
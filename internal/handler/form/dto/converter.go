@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/i18n"
 	"github.com/platonso/hrmate/internal/service/form/model"
 )
 
@@ -14,7 +15,8 @@ func ToFormCreateInput(req FormCreateRequest) model.FormCreateInput {
 	}
 }
 
-func ToFormResponse(form *domain.Form) FormResponse {
+// ToFormResponse converts the form; the status label is translated to the given locale.
+func ToFormResponse(form *domain.Form, locale string) FormResponse {
 	return FormResponse{
 		ID:          form.ID,
 		UserID:      form.UserID,
@@ -25,17 +27,19 @@ func ToFormResponse(form *domain.Form) FormResponse {
 		CreatedAt:   form.CreatedAt,
 		ReviewedAt:  form.ReviewedAt,
 		Status:      string(form.Status),
+		StatusLabel: i18n.T(locale, "form.status."+string(form.Status)),
 		Comment:     form.Comment,
+		Version:     form.Version,
 	}
 }
 
-func ToFormResponses(forms []domain.Form) []FormResponse {
+func ToFormResponses(forms []domain.Form, locale string) []FormResponse {
 	if len(forms) == 0 {
 		return []FormResponse{}
 	}
 	responses := make([]FormResponse, len(forms))
 	for i := range forms {
-		responses[i] = ToFormResponse(&forms[i])
+		responses[i] = ToFormResponse(&forms[i], locale)
 	}
 	return responses
 }
@@ -52,7 +56,7 @@ func ToUserResponse(user *domain.User) UserResponse {
 	}
 }
 
-func ToFormsWithUserResponses(data []model.FormsWithUser) []FormsWithUserResponse {
+func ToFormsWithUserResponses(data []model.FormsWithUser, locale string) []FormsWithUserResponse {
 	if len(data) == 0 {
 		return []FormsWithUserResponse{}
 	}
@@ -60,8 +64,29 @@ func ToFormsWithUserResponses(data []model.FormsWithUser) []FormsWithUserRespons
 	for i := range data {
 		responses[i] = FormsWithUserResponse{
 			User:  ToUserResponse(&data[i].User),
-			Forms: ToFormResponses(data[i].Forms),
+			Forms: ToFormResponses(data[i].Forms, locale),
 		}
 	}
 	return responses
 }
+
+func ToFormRecords(forms []domain.Form) []FormRecord {
+	records := make([]FormRecord, len(forms))
+	for i, form := range forms {
+		records[i] = FormRecord{
+			ID:          form.ID,
+			UserID:      form.UserID,
+			ExecutorID:  form.ExecutorID,
+			Title:       form.Title,
+			Description: form.Description,
+			StartDate:   form.StartDate,
+			EndDate:     form.EndDate,
+			CreatedAt:   form.CreatedAt,
+			ReviewedAt:  form.ReviewedAt,
+			Status:      string(form.Status),
+			Comment:     form.Comment,
+			Version:     form.Version,
+		}
+	}
+	return records
+}
@@ -26,10 +26,31 @@ type FormResponse struct {
 	StartDate *time.Time `json:"startDate"`
 	EndDate   *time.Time `json:"endDate"`
 
-	CreatedAt  time.Time  `json:"createdAt"`
-	ReviewedAt *time.Time `json:"reviewedAt"`
-	Status     string     `json:"status"`
-	Comment    *string    `json:"comment"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ReviewedAt  *time.Time `json:"reviewedAt"`
+	Status      string     `json:"status"`
+	StatusLabel string     `json:"statusLabel"` // status in the language of the request
+	Comment     *string    `json:"comment"`
+	Version     int        `json:"version"`
+}
+
+// FormRecord is a form in the shape GET /forms has always returned, the field names
+// of the domain model. It is kept for v1 clients, the other endpoints use FormResponse.
+type FormRecord struct {
+	ID          uuid.UUID `json:"ID"`
+	UserID      uuid.UUID `json:"UserID"`
+	ExecutorID  uuid.UUID `json:"ExecutorID"`
+	Title       string    `json:"Title"`
+	Description string    `json:"Description"`
+
+	StartDate *time.Time `json:"StartDate"`
+	EndDate   *time.Time `json:"EndDate"`
+
+	CreatedAt  time.Time  `json:"CreatedAt"`
+	ReviewedAt *time.Time `json:"ReviewedAt"`
+	Status     string     `json:"Status"`
+	Comment    *string    `json:"Comment"`
+	Version    int        `json:"Version"`
 }
 
 type UserResponse struct {
@@ -13,6 +13,7 @@ import (
 	"github.com/platonso/hrmate/internal/handler/middleware"
 	"github.com/platonso/hrmate/internal/handler/request"
 	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/i18n"
 	formservice "github.com/platonso/hrmate/internal/service/form"
 	"github.com/platonso/hrmate/internal/service/form/model"
 )
@@ -22,8 +23,8 @@ type Service interface {
 	GetForm(ctx context.Context, formID uuid.UUID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.Form, error)
 	GetForms(ctx context.Context, filter *formservice.Filter, requesterID uuid.UUID, requesterRole domain.Role) ([]domain.Form, error)
 	GetFormsWithUsers(ctx context.Context, filter *formservice.Filter, requesterID uuid.UUID, requesterRole domain.Role) ([]model.FormsWithUser, error)
-	Approve(ctx context.Context, formID uuid.UUID, comment string) (*domain.Form, error)
-	Reject(ctx context.Context, formID uuid.UUID, comment string) (*domain.Form, error)
+	Approve(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error)
+	Reject(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error)
 }
 
 type Handler struct {
@@ -40,13 +41,13 @@ func (h *Handler) HandleCreateForm(w http.ResponseWriter, r *http.Request) {
 	var req dto.FormCreateRequest
 
 	if err := request.DecodeAndValidate(r, &req); err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid request format")
+		response.WriteError(w, r, err, "invalid request format")
 		return
 	}
 
 	requesterID, ok := middleware.GetUserID(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
@@ -54,95 +55,98 @@ func (h *Handler) HandleCreateForm(w http.ResponseWriter, r *http.Request) {
 
 	form, err := h.svc.Create(r.Context(), &formCreateInput, requesterID)
 	if err != nil {
-		response.WriteError(w, err, "failed to create form")
+		response.WriteError(w, r, err, "failed to create form")
 		return
 	}
 
-	response.WriteJSON(w, http.StatusCreated, dto.ToFormResponse(form))
+	response.SetETag(w, form.Version)
+	response.WriteJSON(w, http.StatusCreated, dto.ToFormResponse(form, i18n.FromContext(r.Context())))
 }
 
 func (h *Handler) HandleGetForm(w http.ResponseWriter, r *http.Request) {
 	formID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid form id format")
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid form id format")
 		return
 	}
 
 	requesterID, ok := middleware.GetUserID(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
 	requesterRole, ok := middleware.GetUserRole(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
 	form, err := h.svc.GetForm(r.Context(), formID, requesterID, requesterRole)
 	if err != nil {
-		response.WriteError(w, err, "failed to get form")
+		response.WriteError(w, r, err, "failed to get form")
 		return
 	}
 
-	response.WriteJSON(w, http.StatusOK, dto.ToFormResponse(form))
+	response.SetETag(w, form.Version)
+	response.WriteJSON(w, http.StatusOK, dto.ToFormResponse(form, i18n.FromContext(r.Context())))
 }
 
 func (h *Handler) HandleGetForms(w http.ResponseWriter, r *http.Request) {
 	requesterID, ok := middleware.GetUserID(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
 	requesterRole, ok := middleware.GetUserRole(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
 	filter, err := parseFilter(r)
 	if err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid filter parameters")
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid filter parameters")
 		return
 	}
 
 	forms, err := h.svc.GetForms(r.Context(), filter, requesterID, requesterRole)
 	if err != nil {
-		response.WriteError(w, err, "failed to get forms")
+		response.WriteError(w, r, err, "failed to get forms")
 		return
 	}
 
-	response.WriteJSON(w, http.StatusOK, forms)
+	// v1 keeps the original shape of this list, the mobile app depends on it
+	response.WriteJSON(w, http.StatusOK, dto.ToFormRecords(forms))
 }
 
 func (h *Handler) HandleGetFormsWithUsers(w http.ResponseWriter, r *http.Request) {
 	requesterID, ok := middleware.GetUserID(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
 	requesterRole, ok := middleware.GetUserRole(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
 	filter, err := parseFilter(r)
 	if err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid filter parameters")
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid filter parameters")
 		return
 	}
 
 	formsWithUsers, err := h.svc.GetFormsWithUsers(r.Context(), filter, requesterID, requesterRole)
 	if err != nil {
-		response.WriteError(w, err, "failed to get forms")
+		response.WriteError(w, r, err, "failed to get forms")
 		return
 	}
 
-	response.WriteJSON(w, http.StatusOK, dto.ToFormsWithUserResponses(formsWithUsers))
+	response.WriteJSON(w, http.StatusOK, dto.ToFormsWithUserResponses(formsWithUsers, i18n.FromContext(r.Context())))
 }
 
 func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
@@ -177,25 +181,33 @@ func parseFilter(r *http.Request) (*formservice.Filter, error) {
 func handleFormAction(
 	w http.ResponseWriter,
 	r *http.Request,
-	action func(ctx context.Context, formID uuid.UUID, comment string) (*domain.Form, error),
+	action func(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error),
 ) {
 	formID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid form id format")
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid form id format")
 		return
 	}
+
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		response.WriteError(w, r, err, "the form has been modified")
+		return
+	}
+
 	var req dto.FormCommentRequest
 
 	if err := request.DecodeAndValidate(r, &req); err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid request format")
+		response.WriteError(w, r, err, "invalid request format")
 		return
 	}
 
-	form, err := action(r.Context(), formID, req.Comment)
+	form, err := action(r.Context(), formID, req.Comment, expectedVersion)
 	if err != nil {
-		response.WriteError(w, err, "failed to process form action")
+		response.WriteError(w, r, err, "failed to process form action")
 		return
 	}
 
-	response.WriteJSON(w, http.StatusOK, dto.ToFormResponse(form))
+	response.SetETag(w, form.Version)
+	response.WriteJSON(w, http.StatusOK, dto.ToFormResponse(form, i18n.FromContext(r.Context())))
 }
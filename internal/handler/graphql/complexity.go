@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// listComplexity is the number of items a list field is assumed to return
+// when its real length is unknown up front.
+const listComplexity = 10
+
+// A paginated field such as users returns as many nodes as its first argument
+// asks for, with the defaults and the cap of the user service.
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// complexityLimit rejects queries before they run, so a single request cannot
+// fan out into thousands of lookups.
+type complexityLimit struct {
+	schema *ast.Schema
+	max    int
+}
+
+func newComplexityLimit(source string, max int) (*complexityLimit, error) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: source})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load graphql schema: %w", err)
+	}
+
+	return &complexityLimit{schema: schema, max: max}, nil
+}
+
+// allows reports the complexity of the operation and whether it is within the limit.
+// Invalid queries are allowed, the executor reports their errors in its own format.
+func (c *complexityLimit) allows(query, operationName string, variables map[string]any) (int, bool) {
+	if c.max <= 0 {
+		return 0, true
+	}
+
+	doc, errList := gqlparser.LoadQuery(c.schema, query)
+	if len(errList) > 0 {
+		return 0, true
+	}
+
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return 0, true
+	}
+
+	complexity := selectionComplexity(op.SelectionSet, variables, listComplexity)
+	return complexity, complexity <= c.max
+}
+
+// selectionComplexity prices the selections, multiplying the children of list fields
+// by listSize. A field taking a first argument is a page: the lists of its selections,
+// the nodes, are priced by the requested page size instead.
+func selectionComplexity(set ast.SelectionSet, variables map[string]any, listSize int) int {
+	total := 0
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *ast.Field:
+			childListSize := listComplexity
+			if s.Definition != nil && s.Definition.Arguments.ForName("first") != nil {
+				childListSize = defaultPageSize
+				if first := s.Arguments.ForName("first"); first != nil {
+					childListSize = pageSize(first.Value, variables)
+				}
+			}
+
+			children := selectionComplexity(s.SelectionSet, variables, childListSize)
+			if s.Definition != nil && s.Definition.Type.Elem != nil {
+				children *= listSize
+			}
+			total += 1 + children
+		case *ast.InlineFragment:
+			total += selectionComplexity(s.SelectionSet, variables, listSize)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				total += selectionComplexity(s.Definition.SelectionSet, variables, listSize)
+			}
+		}
+	}
+	return total
+}
+
+// pageSize is the number of nodes a first argument asks for. Invalid values are
+// priced as the default, the resolver rejects them anyway.
+func pageSize(value *ast.Value, variables map[string]any) int {
+	var size int
+	switch v, _ := value.Value(variables); v := v.(type) {
+	case int64:
+		size = int(min(v, maxPageSize))
+	case float64:
+		size = int(min(v, maxPageSize))
+	default:
+		return defaultPageSize
+	}
+
+	if size < 1 {
+		return defaultPageSize
+	}
+	return size
+}
@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	gqlgo "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/middleware"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+	userservice "github.com/platonso/hrmate/internal/service/user"
+)
+
+//go:embed schema.graphql
+var schemaSource string
+
+type UserService interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	GetUser(ctx context.Context, userID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.User, error)
+	GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID, requesterID uuid.UUID, requesterRole domain.Role) ([]domain.User, error)
+	SearchUsers(ctx context.Context, filter *userservice.Filter, requesterRole domain.Role) (*userservice.Page, error)
+}
+
+type FormService interface {
+	GetForm(ctx context.Context, formID uuid.UUID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.Form, error)
+	GetForms(ctx context.Context, filter *formservice.Filter, requesterID uuid.UUID, requesterRole domain.Role) ([]domain.Form, error)
+}
+
+// Limits bound the cost of a single query.
+type Limits struct {
+	MaxDepth int
+	// MaxComplexity is the number of fields a query may resolve, counting each list as listComplexity items
+	MaxComplexity int
+}
+
+type Handler struct {
+	schema     *gqlgo.Schema
+	complexity *complexityLimit
+	userSvc    UserService
+	formSvc    FormService
+}
+
+func NewHandler(userSvc UserService, formSvc FormService, limits Limits) (*Handler, error) {
+	schema, err := gqlgo.ParseSchema(schemaSource, &resolver{userSvc: userSvc, formSvc: formSvc},
+		gqlgo.UseStringDescriptions(),
+		gqlgo.MaxDepth(limits.MaxDepth),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse graphql schema: %w", err)
+	}
+
+	complexity, err := newComplexityLimit(schemaSource, limits.MaxComplexity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		schema:     schema,
+		complexity: complexity,
+		userSvc:    userSvc,
+		formSvc:    formSvc,
+	}, nil
+}
+
+type queryRequest struct {
+	Query         string         `json:"query" validate:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// HandleQuery executes a query sent as JSON with POST, or in the query string with GET.
+// Like any GraphQL server it answers 200 with the errors in the body once the query is parsed.
+func (h *Handler) HandleQuery(w http.ResponseWriter, r *http.Request) {
+	requesterID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	requesterRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	req, err := decodeQuery(r)
+	if err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	if complexity, ok := h.complexity.allows(req.Query, req.OperationName, req.Variables); !ok {
+		response.WriteJSON(w, http.StatusOK, &gqlgo.Response{Errors: []*gqlerrors.QueryError{{
+			Message:    fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, h.complexity.max),
+			Extensions: map[string]any{"code": errs.ErrQueryTooComplex.Error()},
+		}}})
+		return
+	}
+
+	ctx := withLoaders(r.Context(), newLoaders(h.userSvc, h.formSvc, requesterID, requesterRole))
+
+	response.WriteJSON(w, http.StatusOK, h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+func decodeQuery(r *http.Request) (*queryRequest, error) {
+	var req queryRequest
+
+	if r.Method != http.MethodGet {
+		if err := request.DecodeAndValidate(r, &req); err != nil {
+			return nil, err
+		}
+		return &req, nil
+	}
+
+	query := r.URL.Query()
+	req.Query = query.Get("query")
+	req.OperationName = query.Get("operationName")
+	if req.Query == "" {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	if variables := query.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+			return nil, errs.ErrInvalidRequest
+		}
+	}
+
+	return &req, nil
+}
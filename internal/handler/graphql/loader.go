@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/platonso/hrmate/internal/domain"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+)
+
+type contextKey string
+
+const loadersKey contextKey = "loaders"
+
+// formsKey selects the forms of one author, with any status when status is empty.
+type formsKey struct {
+	userID uuid.UUID
+	status domain.FormStatus
+}
+
+// loaders batch the lookups of related users and forms made while resolving one query,
+// so a list of forms costs one query for its authors instead of one per form.
+// They are created per request, as results depend on what the requester may see.
+type loaders struct {
+	users *dataloader.Loader[uuid.UUID, *domain.User]
+	forms *dataloader.Loader[formsKey, []domain.Form]
+}
+
+func newLoaders(userSvc UserService, formSvc FormService, requesterID uuid.UUID, requesterRole domain.Role) *loaders {
+	loadUsers := func(ctx context.Context, userIDs []uuid.UUID) []*dataloader.Result[*domain.User] {
+		results := make([]*dataloader.Result[*domain.User], len(userIDs))
+
+		users, err := userSvc.GetUsersByIDs(ctx, userIDs, requesterID, requesterRole)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*domain.User]{Error: err}
+			}
+			return results
+		}
+
+		byID := make(map[uuid.UUID]*domain.User, len(users))
+		for i := range users {
+			byID[users[i].ID] = &users[i]
+		}
+
+		// Unknown and hidden users resolve to null
+		for i, userID := range userIDs {
+			results[i] = &dataloader.Result[*domain.User]{Data: byID[userID]}
+		}
+		return results
+	}
+
+	loadForms := func(ctx context.Context, keys []formsKey) []*dataloader.Result[[]domain.Form] {
+		results := make([]*dataloader.Result[[]domain.Form], len(keys))
+
+		// One query per requested status, usually just one
+		userIDsByStatus := make(map[domain.FormStatus][]uuid.UUID)
+		for _, key := range keys {
+			userIDsByStatus[key.status] = append(userIDsByStatus[key.status], key.userID)
+		}
+
+		formsByKey := make(map[formsKey][]domain.Form)
+		errByStatus := make(map[domain.FormStatus]error)
+		for status, userIDs := range userIDsByStatus {
+			filter := &formservice.Filter{UserIDs: userIDs}
+			if status != "" {
+				filter.FormStatus = &status
+			}
+
+			forms, err := formSvc.GetForms(ctx, filter, requesterID, requesterRole)
+			if err != nil {
+				errByStatus[status] = err
+				continue
+			}
+
+			for _, form := range forms {
+				key := formsKey{userID: form.UserID, status: status}
+				formsByKey[key] = append(formsByKey[key], form)
+			}
+		}
+
+		for i, key := range keys {
+			if err := errByStatus[key.status]; err != nil {
+				results[i] = &dataloader.Result[[]domain.Form]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[[]domain.Form]{Data: formsByKey[key]}
+		}
+		return results
+	}
+
+	return &loaders{
+		users: dataloader.NewBatchedLoader(loadUsers),
+		forms: dataloader.NewBatchedLoader(loadForms),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey).(*loaders)
+	return l
+}
@@ -0,0 +1,349 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	gqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/middleware"
+	"github.com/platonso/hrmate/internal/i18n"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+	userservice "github.com/platonso/hrmate/internal/service/user"
+)
+
+// queryError exposes the error code of the REST API as extensions.code.
+type queryError struct {
+	err error
+	msg string
+}
+
+func (e *queryError) Error() string {
+	return e.msg
+}
+
+func (e *queryError) Extensions() map[string]any {
+	return map[string]any{"code": e.err.Error()}
+}
+
+func newQueryError(err error, msg string) error {
+	return &queryError{err: err, msg: msg}
+}
+
+type resolver struct {
+	userSvc UserService
+	formSvc FormService
+}
+
+func (r *resolver) Me(ctx context.Context) (*userResolver, error) {
+	requesterID, _, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userSvc.GetUserByID(ctx, requesterID)
+	if err != nil {
+		return nil, newQueryError(err, "failed to get user")
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID gqlgo.ID }) (*userResolver, error) {
+	if err := requireScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+
+	requesterID, requesterRole, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return nil, newQueryError(errs.ErrInvalidRequest, "invalid user id format")
+	}
+
+	user, err := r.userSvc.GetUser(ctx, userID, requesterID, requesterRole)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, newQueryError(err, "failed to get user")
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+func (r *resolver) Users(ctx context.Context, args struct {
+	Role       *string
+	IsActive   *bool
+	Department *string
+	Search     *string
+	First      *int32
+	After      *string
+}) (*userPageResolver, error) {
+	if err := requireScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+
+	_, requesterRole, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &userservice.Filter{
+		IsActive:   args.IsActive,
+		Department: args.Department,
+	}
+	if args.Role != nil {
+		role := fromEnum[domain.Role](*args.Role)
+		filter.Role = &role
+	}
+	if args.Search != nil {
+		filter.Search = *args.Search
+	}
+	if args.First != nil {
+		if *args.First < 1 {
+			return nil, newQueryError(errs.ErrInvalidRequest, "first must be positive")
+		}
+		filter.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		cursor, err := userservice.ParseCursor(*args.After)
+		if err != nil {
+			return nil, newQueryError(errs.ErrInvalidRequest, "invalid cursor")
+		}
+		filter.After = cursor
+	}
+
+	page, err := r.userSvc.SearchUsers(ctx, filter, requesterRole)
+	if err != nil {
+		return nil, newQueryError(err, "failed to get users")
+	}
+
+	return &userPageResolver{page: page}, nil
+}
+
+func (r *resolver) Form(ctx context.Context, args struct{ ID gqlgo.ID }) (*formResolver, error) {
+	if err := requireScope(ctx, domain.ScopeFormsRead); err != nil {
+		return nil, err
+	}
+
+	requesterID, requesterRole, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	formID, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return nil, newQueryError(errs.ErrInvalidRequest, "invalid form id format")
+	}
+
+	form, err := r.formSvc.GetForm(ctx, formID, requesterID, requesterRole)
+	if err != nil {
+		if errors.Is(err, errs.ErrFormNotFound) {
+			return nil, nil
+		}
+		return nil, newQueryError(err, "failed to get form")
+	}
+
+	return &formResolver{form: *form}, nil
+}
+
+func (r *resolver) Forms(ctx context.Context, args struct {
+	UserID     *gqlgo.ID
+	ExecutorID *gqlgo.ID
+	Status     *string
+}) ([]*formResolver, error) {
+	if err := requireScope(ctx, domain.ScopeFormsRead); err != nil {
+		return nil, err
+	}
+
+	requesterID, requesterRole, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &formservice.Filter{}
+	if args.UserID != nil {
+		userID, err := uuid.Parse(string(*args.UserID))
+		if err != nil {
+			return nil, newQueryError(errs.ErrInvalidRequest, "invalid user id format")
+		}
+		filter.UserID = &userID
+	}
+	if args.ExecutorID != nil {
+		executorID, err := uuid.Parse(string(*args.ExecutorID))
+		if err != nil {
+			return nil, newQueryError(errs.ErrInvalidRequest, "invalid executor id format")
+		}
+		filter.ExecutorID = &executorID
+	}
+	if args.Status != nil {
+		status := fromEnum[domain.FormStatus](*args.Status)
+		filter.FormStatus = &status
+	}
+
+	forms, err := r.formSvc.GetForms(ctx, filter, requesterID, requesterRole)
+	if err != nil {
+		return nil, newQueryError(err, "failed to get forms")
+	}
+
+	return toFormResolvers(forms), nil
+}
+
+type userPageResolver struct {
+	page *userservice.Page
+}
+
+func (p *userPageResolver) Nodes() []*userResolver {
+	result := make([]*userResolver, len(p.page.Users))
+	for i, user := range p.page.Users {
+		result[i] = &userResolver{user: user}
+	}
+	return result
+}
+
+func (p *userPageResolver) EndCursor() *string {
+	if p.page.Next == nil {
+		return nil
+	}
+	cursor := p.page.Next.Encode()
+	return &cursor
+}
+
+type userResolver struct {
+	user domain.User
+}
+
+func (u *userResolver) ID() gqlgo.ID        { return gqlgo.ID(u.user.ID.String()) }
+func (u *userResolver) Role() string        { return toEnum(u.user.Role) }
+func (u *userResolver) FirstName() string   { return u.user.FirstName }
+func (u *userResolver) LastName() string    { return u.user.LastName }
+func (u *userResolver) Position() string    { return u.user.Position }
+func (u *userResolver) Email() string       { return u.user.Email }
+func (u *userResolver) IsActive() bool      { return u.user.IsActive }
+func (u *userResolver) Department() *string { return optional(u.user.Department) }
+func (u *userResolver) Version() int32      { return int32(u.user.Version) }
+
+func (u *userResolver) Forms(ctx context.Context, args struct{ Status *string }) ([]*formResolver, error) {
+	if err := requireScope(ctx, domain.ScopeFormsRead); err != nil {
+		return nil, err
+	}
+
+	key := formsKey{userID: u.user.ID}
+	if args.Status != nil {
+		key.status = fromEnum[domain.FormStatus](*args.Status)
+	}
+
+	forms, err := loadersFrom(ctx).forms.Load(ctx, key)()
+	if err != nil {
+		return nil, newQueryError(err, "failed to get forms")
+	}
+
+	return toFormResolvers(forms), nil
+}
+
+type formResolver struct {
+	form domain.Form
+}
+
+func (f *formResolver) ID() gqlgo.ID            { return gqlgo.ID(f.form.ID.String()) }
+func (f *formResolver) Title() string           { return f.form.Title }
+func (f *formResolver) Description() string     { return f.form.Description }
+func (f *formResolver) StartDate() *gqlgo.Time  { return toTime(f.form.StartDate) }
+func (f *formResolver) EndDate() *gqlgo.Time    { return toTime(f.form.EndDate) }
+func (f *formResolver) CreatedAt() gqlgo.Time   { return gqlgo.Time{Time: f.form.CreatedAt} }
+func (f *formResolver) ReviewedAt() *gqlgo.Time { return toTime(f.form.ReviewedAt) }
+func (f *formResolver) Status() string          { return toEnum(f.form.Status) }
+func (f *formResolver) Comment() *string        { return f.form.Comment }
+func (f *formResolver) Version() int32          { return int32(f.form.Version) }
+
+func (f *formResolver) StatusLabel(ctx context.Context) string {
+	return i18n.T(i18n.FromContext(ctx), "form.status."+string(f.form.Status))
+}
+
+func (f *formResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, f.form.UserID)
+}
+
+func (f *formResolver) Executor(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, f.form.ExecutorID)
+}
+
+func loadUser(ctx context.Context, userID uuid.UUID) (*userResolver, error) {
+	if err := requireScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+
+	user, err := loadersFrom(ctx).users.Load(ctx, userID)()
+	if err != nil {
+		return nil, newQueryError(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+func requester(ctx context.Context) (uuid.UUID, domain.Role, error) {
+	requesterID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return uuid.Nil, "", newQueryError(errs.ErrUnauthorized, "authentication required")
+	}
+
+	requesterRole, ok := middleware.GetUserRole(ctx)
+	if !ok {
+		return uuid.Nil, "", newQueryError(errs.ErrUnauthorized, "authentication required")
+	}
+
+	return requesterID, requesterRole, nil
+}
+
+// requireScope restricts API tokens per field, as a query may read both forms and users.
+// Interactive sessions are not limited by scopes.
+func requireScope(ctx context.Context, scope domain.Scope) error {
+	tokenScopes, ok := middleware.GetTokenScopes(ctx)
+	if !ok || slices.Contains(tokenScopes, scope) {
+		return nil
+	}
+	return newQueryError(errs.ErrInsufficientScope, "token lacks required scope: "+string(scope))
+}
+
+func toFormResolvers(forms []domain.Form) []*formResolver {
+	result := make([]*formResolver, len(forms))
+	for i := range forms {
+		result[i] = &formResolver{form: forms[i]}
+	}
+	return result
+}
+
+// toEnum and fromEnum convert between the lower case domain values and GraphQL enum values.
+func toEnum[T ~string](value T) string {
+	return strings.ToUpper(string(value))
+}
+
+func fromEnum[T ~string](value string) T {
+	return T(strings.ToLower(value))
+}
+
+func toTime(t *time.Time) *gqlgo.Time {
+	if t == nil {
+		return nil
+	}
+	return &gqlgo.Time{Time: *t}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/platonso/hrmate/internal/service/health/model"
+
+func ToHealthResponse(report *model.Report) HealthResponse {
+	resp := HealthResponse{
+		Status: StatusOK,
+		Checks: make([]CheckResponse, len(report.Checks)),
+	}
+	if !report.Ready() {
+		resp.Status = StatusUnavailable
+	}
+
+	for i, check := range report.Checks {
+		resp.Checks[i] = CheckResponse{
+			Name:   check.Name,
+			Status: StatusOK,
+			Error:  check.Err,
+		}
+		if !check.Healthy() {
+			resp.Checks[i].Status = StatusUnavailable
+		}
+	}
+	return resp
+}
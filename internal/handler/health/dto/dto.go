@@ -0,0 +1,17 @@
+package dto
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+type CheckResponse struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type HealthResponse struct {
+	Status string          `json:"status"`
+	Checks []CheckResponse `json:"checks,omitempty"`
+}
@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/platonso/hrmate/internal/handler/health/dto"
+	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/service/health/model"
+)
+
+type Service interface {
+	Readiness(ctx context.Context) *model.Report
+}
+
+type Handler struct {
+	svc Service
+}
+
+func NewHandler(svc Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+// HandleLiveness answers as long as the process can serve HTTP at all.
+// It deliberately checks no dependencies, so a database outage does not restart every instance.
+func (h *Handler) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	response.WriteJSON(w, http.StatusOK, dto.HealthResponse{Status: dto.StatusOK})
+}
+
+func (h *Handler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	report := h.svc.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.WriteJSON(w, status, dto.ToHealthResponse(report))
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/handler/request"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+}
+
+type Audit struct {
+	AuditSvc AuditService
+}
+
+// Record writes an audit entry for every state-changing request of an authenticated user.
+// It must run after AuthMiddleware.
+func (m *Audit) Record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isReadOnly(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		userID, ok := GetUserID(r.Context())
+		if !ok {
+			return
+		}
+
+		actorID := userID
+		var subjectID *uuid.UUID
+		if realActorID, ok := GetActorID(r.Context()); ok {
+			actorID = realActorID
+			subjectID = &userID
+		}
+
+		var resourceID *uuid.UUID
+		if id, err := uuid.Parse(chi.URLParam(r, "id")); err == nil {
+			resourceID = &id
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		entry := domain.NewAuditEntry(
+			actorID,
+			subjectID,
+			resourceID,
+			r.Method,
+			chi.RouteContext(r.Context()).RoutePattern(),
+			r.URL.Path,
+			status,
+			request.ClientIP(r),
+		)
+
+		// Errors are logged by the service, the response has already been sent
+		_ = m.AuditSvc.Record(context.WithoutCancel(r.Context()), &entry)
+	})
+}
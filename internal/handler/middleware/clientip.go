@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/platonso/hrmate/internal/handler/request"
+)
+
+// ClientIP resolves the client address once per request, so sessions, rate limits,
+// audit entries and logs all see the same one. Forwarding headers are only believed
+// when the peer is a trusted proxy, otherwise any client could pick its address.
+type ClientIP struct {
+	TrustedProxies []netip.Prefix
+}
+
+func (m *ClientIP) Handle(next http.Handler) http.Handler {
+	if len(m.TrustedProxies) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := request.WithClientIP(r.Context(), m.resolve(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (m *ClientIP) resolve(r *http.Request) string {
+	peer := request.PeerIP(r)
+
+	addr, err := netip.ParseAddr(peer)
+	if err != nil || !m.trusted(addr) {
+		return peer
+	}
+
+	if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		// Every proxy appends the address it received the request from, so the
+		// client is the rightmost address not belonging to a trusted proxy
+		hops := strings.Split(strings.Join(values, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			addr = hop.Unmap()
+			if !m.trusted(addr) {
+				break
+			}
+		}
+		return addr.String()
+	}
+
+	if realIP, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return realIP.Unmap().String()
+	}
+
+	return peer
+}
+
+func (m *ClientIP) trusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range m.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -16,3 +16,22 @@ func GetUserID(ctx context.Context) (uuid.UUID, bool) {
 	id, ok := ctx.Value(userIDKey).(uuid.UUID)
 	return id, ok
 }
+
+// GetTokenScopes returns the scopes of the API token the request was authenticated with.
+// ok is false for requests authenticated with a JWT.
+func GetTokenScopes(ctx context.Context) ([]domain.Scope, bool) {
+	scopes, ok := ctx.Value(tokenScopesKey).([]domain.Scope)
+	return scopes, ok
+}
+
+// GetSessionID returns the session of an interactive request.
+func GetSessionID(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(sessionIDKey).(uuid.UUID)
+	return id, ok
+}
+
+// GetActorID returns the admin behind an impersonation session.
+func GetActorID(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(actorIDKey).(uuid.UUID)
+	return id, ok
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// LegacyRoutes serves the unversioned paths of an API version, e.g. /forms for
+// /api/v1/forms, and announces their retirement in the Deprecation, Sunset and Link headers.
+// The request is rewritten before routing, so it is handled, validated and measured
+// exactly like the versioned one.
+type LegacyRoutes struct {
+	Routes chi.Routes
+	Prefix string
+	// DeprecatedAt and Sunset are omitted from the response when zero
+	DeprecatedAt time.Time
+	Sunset       time.Time
+}
+
+func (m *LegacyRoutes) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if strings.HasPrefix(path, "/api/") || m.Routes.Match(chi.NewRouteContext(), r.Method, path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		target := m.Prefix + path
+		if !m.Routes.Match(chi.NewRouteContext(), r.Method, target) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		if !m.DeprecatedAt.IsZero() {
+			h.Set(DeprecationHeader, "@"+strconv.FormatInt(m.DeprecatedAt.Unix(), 10))
+		}
+		if !m.Sunset.IsZero() {
+			h.Set(SunsetHeader, m.Sunset.UTC().Format(http.TimeFormat))
+		}
+		h.Add("Link", "<"+target+`>; rel="successor-version"`)
+
+		u := *r.URL
+		u.Path = target
+		if u.RawPath != "" {
+			u.RawPath = m.Prefix + u.RawPath
+		}
+
+		r = r.WithContext(r.Context())
+		r.URL = &u
+
+		next.ServeHTTP(w, r)
+	})
+}
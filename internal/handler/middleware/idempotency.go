@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, userID uuid.UUID, key, method, path, fingerprint string) (*domain.IdempotencyKey, error)
+	Complete(ctx context.Context, userID uuid.UUID, key string, statusCode int, contentType string, body []byte)
+	Release(ctx context.Context, userID uuid.UUID, key string)
+}
+
+type Idempotency struct {
+	IdempotencySvc IdempotencyService
+}
+
+// Handle makes mutating requests that carry an Idempotency-Key header safe to retry:
+// the first response is stored and replayed for later requests with the same key.
+// Keys are scoped to the user, so it must run after AuthMiddleware.
+func (m *Idempotency) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" || isReadOnlyMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			response.WriteError(w, r, errs.ErrInvalidRequest, "idempotency key is too long")
+			return
+		}
+
+		userID, ok := GetUserID(r.Context())
+		if !ok {
+			response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if request.IsBodyTooLarge(err) {
+			response.WriteError(w, r, errs.ErrBodyTooLarge, "request body is too large")
+			return
+		}
+		if err != nil {
+			response.WriteError(w, r, errs.ErrInvalidRequest, "failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		fingerprint := hex.EncodeToString(sum[:])
+
+		stored, err := m.IdempotencySvc.Begin(r.Context(), userID, key, r.Method, r.URL.Path, fingerprint)
+		if err != nil {
+			response.WriteError(w, r, err, "idempotency key cannot be used")
+			return
+		}
+		if stored != nil {
+			replay(w, stored)
+			return
+		}
+
+		var buf bytes.Buffer
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(&buf)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		// The response has already been sent, the key must be settled even if the client is gone
+		ctx := context.WithoutCancel(r.Context())
+		if status >= http.StatusInternalServerError {
+			// Server errors are not final, let the client retry with the same key
+			m.IdempotencySvc.Release(ctx, userID, key)
+			return
+		}
+		m.IdempotencySvc.Complete(ctx, userID, key, status, ww.Header().Get("Content-Type"), buf.Bytes())
+	})
+}
+
+func replay(w http.ResponseWriter, stored *domain.IdempotencyKey) {
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	_, _ = w.Write(stored.ResponseBody)
+}
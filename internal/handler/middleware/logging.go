@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/logger"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	accessLogKey = "accessLog"
+)
+
+// RequestID takes the request ID from the X-Request-ID header of a trusted proxy or
+// generates one, and echoes it in the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !logger.ValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// accessLog collects the fields that become known only deeper in the chain.
+type accessLog struct {
+	userID  *uuid.UUID
+	actorID *uuid.UUID
+}
+
+type AccessLog struct {
+	Logger *slog.Logger
+}
+
+// Handle puts a request-scoped logger into the context and logs every request
+// once it has been served. It must run after RequestID.
+func (m *AccessLog) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		entry := &accessLog{}
+
+		log := m.Logger.With("request_id", logger.RequestID(r.Context()))
+		ctx := logger.WithContext(r.Context(), log)
+		ctx = context.WithValue(ctx, accessLogKey, entry)
+
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("route", chi.RouteContext(ctx).RoutePattern()),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", request.ClientIP(r)),
+		}
+		if entry.userID != nil {
+			attrs = append(attrs, slog.String("user_id", entry.userID.String()))
+		}
+		if entry.actorID != nil {
+			attrs = append(attrs, slog.String("actor_id", entry.actorID.String()))
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		log.LogAttrs(ctx, level, "request served", attrs...)
+	})
+}
+
+// withLogUser attributes the rest of the request to the authenticated user,
+// both in the context logger and in the access log.
+func withLogUser(ctx context.Context, userID uuid.UUID, actorID *uuid.UUID) context.Context {
+	if entry, ok := ctx.Value(accessLogKey).(*accessLog); ok {
+		entry.userID = &userID
+		entry.actorID = actorID
+	}
+
+	if actorID != nil {
+		return logger.With(ctx, "user_id", userID, "actor_id", *actorID)
+	}
+	return logger.With(ctx, "user_id", userID)
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+type MetricsRecorder interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+type Metrics struct {
+	Recorder MetricsRecorder
+}
+
+// Handle counts requests and their latency by chi route pattern, which is
+// only known once the router has matched the request.
+func (m *Metrics) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		var route string
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+
+		m.Recorder.ObserveRequest(methodLabel(r.Method), route, status, time.Since(start))
+	})
+}
+
+// methodLabel maps methods outside of RFC 9110 to "OTHER": the server accepts any
+// token as a method, and each one would otherwise become a new series.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return "OTHER"
+	}
+}
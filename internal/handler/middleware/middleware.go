@@ -3,86 +3,187 @@ package middleware
 import (
 	"context"
 	"errors"
-	"log"
 	"net/http"
+	"slices"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/platonso/hrmate/internal/domain"
 	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
 	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/i18n"
+	"github.com/platonso/hrmate/internal/logger"
+	authmodel "github.com/platonso/hrmate/internal/service/auth/model"
+	"github.com/platonso/hrmate/internal/service/token"
+	"github.com/platonso/hrmate/internal/service/token/model"
 )
 
 const (
-	userIDKey   = "userID"
-	userRoleKey = "userRole"
+	userIDKey      = "userID"
+	userRoleKey    = "userRole"
+	tokenScopesKey = "tokenScopes"
+	sessionIDKey   = "sessionID"
+	actorIDKey     = "actorID"
+	readOnlyKey    = "readOnly"
+)
+
+const (
+	// ImpersonatedByHeader flags every response served to an impersonation session
+	ImpersonatedByHeader = "X-Impersonated-By"
+	// ImpersonatedUserHeader identifies the user being impersonated
+	ImpersonatedUserHeader = "X-Impersonated-User"
 )
 
 type AuthService interface {
-	GetJWTSecret() string
+	ParseAccessToken(tokenString string) (*authmodel.AccessClaims, error)
+}
+
+type SessionService interface {
+	Validate(ctx context.Context, sessionID, userID uuid.UUID, ipAddress string) error
+}
+
+type TokenService interface {
+	Authenticate(ctx context.Context, rawToken string) (*model.Principal, error)
 }
 
 type UserService interface {
-	IsActive(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
 }
 
 type Auth struct {
-	AuthSvc AuthService
-	UserSvc UserService
+	AuthSvc    AuthService
+	UserSvc    UserService
+	TokenSvc   TokenService
+	SessionSvc SessionService
+	// AllowImpersonatedWrites permits state-changing requests during impersonation.
+	// They are always attributed to the real actor in the audit trail.
+	AllowImpersonatedWrites bool
 }
 
 func (m *Auth) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			response.WriteError(w, errs.ErrUnauthorized, "authorization header is required")
+			response.WriteError(w, r, errs.ErrUnauthorized, "authorization header is required")
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			response.WriteError(w, errs.ErrUnauthorized, "bearer token is required")
+			response.WriteError(w, r, errs.ErrUnauthorized, "bearer token is required")
 			return
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(m.AuthSvc.GetJWTSecret()), nil
-		})
+		if strings.HasPrefix(tokenString, token.Prefix) {
+			m.authenticateAPIToken(w, r, next, tokenString)
+			return
+		}
 
-		if err != nil || !token.Valid {
-			response.WriteError(w, errs.ErrUnauthorized, "invalid token")
+		claims, err := m.AuthSvc.ParseAccessToken(tokenString)
+		if err != nil {
+			response.WriteError(w, r, errs.ErrUnauthorized, "invalid token")
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			response.WriteError(w, errs.ErrUnauthorized, "invalid token claims")
+		userID, userRole, sessionID := claims.UserID, claims.Role, claims.SessionID
+
+		if err := m.SessionSvc.Validate(r.Context(), sessionID, userID, request.ClientIP(r)); err != nil {
+			if errors.Is(err, errs.ErrUnauthorized) {
+				response.WriteError(w, r, errs.ErrUnauthorized, "session has expired or was revoked")
+				return
+			}
+			response.WriteError(w, r, errs.ErrInternalServer, "failed to verify session")
 			return
 		}
 
-		userIDStr, ok1 := claims["id"].(string)
-		userRoleStr, ok2 := claims["role"].(string)
-		if !ok1 || !ok2 {
-			response.WriteError(w, errs.ErrUnauthorized, "invalid token payload: missing id or role")
+		ctx := context.WithValue(r.Context(), userIDKey, userID)
+		ctx = context.WithValue(ctx, userRoleKey, userRole)
+		ctx = context.WithValue(ctx, sessionIDKey, sessionID)
+
+		actorID := claims.ActorID
+		if actorID != nil {
+			w.Header().Set(ImpersonatedByHeader, actorID.String())
+			w.Header().Set(ImpersonatedUserHeader, userID.String())
+
+			if !m.AllowImpersonatedWrites && !isReadOnly(r) {
+				response.WriteError(w, r, errs.ErrImpersonationReadOnly, "changes are not allowed while impersonating")
+				return
+			}
+
+			ctx = context.WithValue(ctx, actorIDKey, *actorID)
+		}
+
+		ctx = withLogUser(ctx, userID, actorID)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (m *Auth) authenticateAPIToken(w http.ResponseWriter, r *http.Request, next http.Handler, rawToken string) {
+	principal, err := m.TokenSvc.Authenticate(r.Context(), rawToken)
+	if err != nil {
+		if errors.Is(err, errs.ErrUnauthorized) {
+			response.WriteError(w, r, errs.ErrUnauthorized, "invalid token")
 			return
 		}
+		response.WriteError(w, r, errs.ErrInternalServer, "failed to verify token")
+		return
+	}
 
-		userRole := domain.Role(userRoleStr)
+	ctx := context.WithValue(r.Context(), userIDKey, principal.UserID)
+	ctx = context.WithValue(ctx, userRoleKey, principal.Role)
+	ctx = context.WithValue(ctx, tokenScopesKey, principal.Scopes)
+	ctx = withLogUser(ctx, principal.UserID, nil)
 
-		userID, err := uuid.Parse(userIDStr)
-		if err != nil {
-			response.WriteError(w, errs.ErrUnauthorized, "invalid user id format in token")
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RequireScopes restricts requests authenticated with an API token to tokens carrying all the scopes.
+// Interactive sessions are not limited by scopes.
+func (m *Auth) RequireScopes(scopes ...domain.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenScopes, ok := GetTokenScopes(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, scope := range scopes {
+				if !slices.Contains(tokenScopes, scope) {
+					response.WriteError(w, r, errs.ErrInsufficientScope, "token lacks required scope: "+string(scope))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RejectAPITokens allows only interactive sessions, e.g. so a leaked token cannot mint new ones.
+func (m *Auth) RejectAPITokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetTokenScopes(r.Context()); ok {
+			response.WriteError(w, r, errs.ErrForbidden, "not allowed with an API token")
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), userIDKey, userID)
-		ctx = context.WithValue(ctx, userRoleKey, userRole)
+		next.ServeHTTP(w, r)
+	})
+}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+// RejectImpersonation allows only the user themselves, e.g. so an admin impersonating
+// them cannot mint credentials that outlive the impersonation.
+func (m *Auth) RejectImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetActorID(r.Context()); ok {
+			response.WriteError(w, r, errs.ErrForbidden, "not allowed while impersonating")
+			return
+		}
+
+		next.ServeHTTP(w, r)
 	})
 }
 
@@ -91,7 +192,7 @@ func (m *Auth) RequireRoles(allowedRoles ...domain.Role) func(http.Handler) http
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userRole, ok := GetUserRole(r.Context())
 			if !ok {
-				response.WriteError(w, errs.ErrUnauthorized, "missing user role in context")
+				response.WriteError(w, r, errs.ErrUnauthorized, "missing user role in context")
 				return
 			}
 
@@ -102,7 +203,7 @@ func (m *Auth) RequireRoles(allowedRoles ...domain.Role) func(http.Handler) http
 				}
 			}
 
-			response.WriteError(w, errs.ErrForbidden, "forbidden")
+			response.WriteError(w, r, errs.ErrForbidden, "forbidden")
 		})
 	}
 }
@@ -111,27 +212,56 @@ func (m *Auth) RequireActiveStatus(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := GetUserID(r.Context())
 		if !ok {
-			response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+			response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 			return
 		}
 
-		isActive, err := m.UserSvc.IsActive(r.Context(), userID)
+		user, err := m.UserSvc.GetUserByID(r.Context(), userID)
 		if err != nil {
 			if errors.Is(err, errs.ErrUserNotFound) {
-				response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+				response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 				return
 			}
-			log.Printf("failed to check isActive status: %v", err)
-			response.WriteError(w, errs.ErrInternalServer, "failed to verify account status")
+			logger.FromContext(r.Context()).Error("failed to check active status", "error", err)
+			response.WriteError(w, r, errs.ErrInternalServer, "failed to verify account status")
 
 			return
 		}
 
-		if !isActive {
-			response.WriteError(w, errs.ErrForbidden, "account is not active")
+		// The stored preference wins over the Accept-Language header
+		if user.Locale != "" {
+			w.Header().Set("Content-Language", user.Locale)
+			r = r.WithContext(i18n.WithLocale(r.Context(), user.Locale))
+		}
+
+		if !user.IsActive {
+			response.WriteError(w, r, errs.ErrForbidden, "account is not active")
 			return
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// ReadOnly marks a route that never changes state whatever its method, e.g. a query
+// sent with POST, so it is allowed while impersonating and left out of the audit log.
+// It must run before AuthMiddleware.
+func ReadOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), readOnlyKey, true)))
+	})
+}
+
+func isReadOnly(r *http.Request) bool {
+	readOnly, _ := r.Context().Value(readOnlyKey).(bool)
+	return readOnly || isReadOnlyMethod(r.Method)
+}
+
+func isReadOnlyMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+)
+
+type RateLimitService interface {
+	Allow(ctx context.Context, group domain.RateLimitGroup, subject string) (*domain.RateLimitDecision, error)
+}
+
+type RateLimit struct {
+	RateLimitSvc RateLimitService
+}
+
+// Limit applies the limits of the route group. Authenticated requests are counted
+// per user, so it must run after AuthMiddleware; anonymous ones per client IP.
+func (m *RateLimit) Limit(group domain.RateLimitGroup) func(http.Handler) http.Handler {
+	return m.limit(func(*http.Request) (domain.RateLimitGroup, bool) {
+		return group, true
+	})
+}
+
+// LimitByRole applies the limits of the group the role of the user works with,
+// for routes shared by all roles. It must run after AuthMiddleware.
+func (m *RateLimit) LimitByRole(next http.Handler) http.Handler {
+	return m.limit(func(r *http.Request) (domain.RateLimitGroup, bool) {
+		role, ok := GetUserRole(r.Context())
+		if !ok {
+			return "", false
+		}
+		return domain.RateLimitGroupOf(role)
+	})(next)
+}
+
+func (m *RateLimit) limit(groupOf func(r *http.Request) (domain.RateLimitGroup, bool)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			group, ok := groupOf(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			subject := "ip:" + request.ClientIP(r)
+			if userID, ok := GetUserID(r.Context()); ok {
+				subject = "user:" + userID.String()
+			}
+
+			decision, err := m.RateLimitSvc.Allow(r.Context(), group, subject)
+			if err != nil || decision == nil {
+				// A broken limiter store must not take the API down with it
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set(RateLimitLimitHeader, strconv.Itoa(decision.Limit))
+			h.Set(RateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+			h.Set(RateLimitResetHeader, seconds(decision.Reset))
+
+			if !decision.Allowed {
+				h.Set("Retry-After", seconds(decision.RetryAfter))
+				response.WriteError(w, r, errs.ErrTooManyRequests, "too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// seconds rounds up, so a client waiting that long is never rejected again.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
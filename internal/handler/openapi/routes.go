@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+)
+
+// optionalExtension marks operations that are only routed when a feature is enabled.
+const optionalExtension = "x-optional"
+
+// CheckRoutes reports routes registered in the router but missing from the
+// specification, and documented operations that are not routed.
+func CheckRoutes(doc *openapi3.T, routes chi.Routes) error {
+	routed := make(map[string]bool)
+
+	if err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routed[operationKey(method, normalizePath(route))] = true
+		return nil
+	}); err != nil {
+		return fmt.Errorf("walk routes: %w", err)
+	}
+
+	var undocumented, unrouted []string
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			key := operationKey(method, path)
+			documented[key] = true
+
+			if !routed[key] && op.Extensions[optionalExtension] != true {
+				unrouted = append(unrouted, key)
+			}
+		}
+	}
+
+	for key := range routed {
+		if !documented[key] {
+			undocumented = append(undocumented, key)
+		}
+	}
+
+	if len(undocumented) == 0 && len(unrouted) == 0 {
+		return nil
+	}
+
+	slices.Sort(undocumented)
+	slices.Sort(unrouted)
+
+	var problems []string
+	if len(undocumented) > 0 {
+		problems = append(problems, "routes missing from the spec: "+strings.Join(undocumented, ", "))
+	}
+	if len(unrouted) > 0 {
+		problems = append(problems, "documented operations without a route: "+strings.Join(unrouted, ", "))
+	}
+
+	return fmt.Errorf("openapi spec and router are out of sync: %s", strings.Join(problems, "; "))
+}
+
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// normalizePath strips the trailing slash chi adds for routes mounted at "/" in a subrouter.
+func normalizePath(route string) string {
+	if len(route) > 1 {
+		return strings.TrimSuffix(route, "/")
+	}
+	return route
+}
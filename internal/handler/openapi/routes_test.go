@@ -0,0 +1,98 @@
+package openapi_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/platonso/hrmate/internal/handler"
+	"github.com/platonso/hrmate/internal/handler/openapi"
+)
+
+type nopRecorder struct{}
+
+func (nopRecorder) ObserveRequest(string, string, int, time.Duration) {}
+
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	spec, err := openapi.Load(context.Background())
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	return spec
+}
+
+func newRoutes(t *testing.T, spec *openapi3.T, legacy bool, metrics http.Handler) chi.Router {
+	t.Helper()
+
+	docs, err := openapi.NewHandler(spec)
+	if err != nil {
+		t.Fatalf("docs handler: %v", err)
+	}
+	validator, err := openapi.NewValidator(spec, openapi.ValidatorOptions{Requests: true})
+	if err != nil {
+		t.Fatalf("validator: %v", err)
+	}
+
+	return handler.NewRouter(handler.Services{}, handler.Options{
+		Legacy:         handler.LegacyOptions{Enabled: legacy},
+		MaxBodyBytes:   1 << 20,
+		Logger:         slog.New(slog.DiscardHandler),
+		Metrics:        nopRecorder{},
+		MetricsHandler: metrics,
+		Docs:           docs,
+		Validator:      validator,
+	}).Routes()
+}
+
+func TestCheckRoutes(t *testing.T) {
+	spec := loadSpec(t)
+	metrics := http.NotFoundHandler()
+
+	tests := []struct {
+		name    string
+		legacy  bool
+		metrics http.Handler
+	}{
+		{name: "default"},
+		{name: "legacy routes", legacy: true},
+		{name: "metrics", metrics: metrics},
+		{name: "legacy routes and metrics", legacy: true, metrics: metrics},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := openapi.CheckRoutes(spec, newRoutes(t, spec, tt.legacy, tt.metrics)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCheckRoutesUndocumentedRoute(t *testing.T) {
+	spec := loadSpec(t)
+	routes := newRoutes(t, spec, false, nil)
+	routes.Get("/api/v1/undocumented", http.NotFound)
+
+	if err := openapi.CheckRoutes(spec, routes); err == nil {
+		t.Fatal("expected an error for a route missing from the spec")
+	}
+}
+
+func TestCheckRoutesUnroutedOperation(t *testing.T) {
+	spec := loadSpec(t)
+	routes := newRoutes(t, spec, false, nil)
+
+	spec.Paths.Set("/api/v1/unrouted", &openapi3.PathItem{
+		Get: &openapi3.Operation{Responses: openapi3.NewResponses()},
+	})
+
+	if err := openapi.CheckRoutes(spec, routes); err == nil {
+		t.Fatal("expected an error for a documented operation without a route")
+	}
+}
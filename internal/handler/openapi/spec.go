@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+//go:embed docs.html
+var docsHTML []byte
+
+// docsCSP allows Swagger UI from unpkg and the inline script of the page, identified by its hash
+var docsCSP = "default-src 'none'; " +
+	"script-src https://unpkg.com " + inlineScriptHashes(docsHTML) + "; " +
+	"style-src https://unpkg.com; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+var inlineScript = regexp.MustCompile(`(?s)<script>(.*?)</script>`)
+
+func inlineScriptHashes(html []byte) string {
+	var hashes []string
+	for _, match := range inlineScript.FindAllSubmatch(html, -1) {
+		sum := sha256.Sum256(match[1])
+		hashes = append(hashes, "'sha256-"+base64.StdEncoding.EncodeToString(sum[:])+"'")
+	}
+	return strings.Join(hashes, " ")
+}
+
+// Load parses and validates the embedded OpenAPI document.
+func Load(ctx context.Context) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(specYAML)
+	if err != nil {
+		return nil, fmt.Errorf("parse openapi spec: %w", err)
+	}
+
+	if err := doc.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Handler serves the specification and the documentation UI.
+type Handler struct {
+	specJSON []byte
+}
+
+func NewHandler(doc *openapi3.T) (*Handler, error) {
+	specJSON, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal openapi spec: %w", err)
+	}
+
+	return &Handler{
+		specJSON: specJSON,
+	}, nil
+}
+
+func (h *Handler) HandleSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.specJSON)
+}
+
+func (h *Handler) HandleDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", docsCSP)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(docsHTML)
+}
@@ -0,0 +1,288 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/google/uuid"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/logger"
+)
+
+func init() {
+	// Path and query identifiers are UUIDs; without this the format is only informational
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewCallbackValidator(func(value string) error {
+		if _, err := uuid.Parse(value); err != nil {
+			return errors.New("not a valid UUID")
+		}
+		return nil
+	}))
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+	// SCIM clients send JSON as application/scim+json, which has no decoder of its own
+	openapi3filter.RegisterBodyDecoder("application/scim+json", openapi3filter.JSONBodyDecoder)
+}
+
+type ValidatorOptions struct {
+	Requests bool
+	// Responses should only be enabled in tests and local development:
+	// responses are buffered and replaced with an error when they don't match the spec.
+	Responses bool
+}
+
+// Validator checks requests and, optionally, responses against the specification.
+type Validator struct {
+	router  routers.Router
+	options ValidatorOptions
+}
+
+func NewValidator(doc *openapi3.T, options ValidatorOptions) (*Validator, error) {
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Validator{
+		router:  router,
+		options: options,
+	}, nil
+}
+
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	if !v.options.Requests && !v.options.Responses {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			// Unknown routes and methods are answered by the router itself
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				// Authentication is enforced by the auth middleware
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				MultiError:         true,
+			},
+		}
+
+		if v.options.Requests {
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				if request.IsBodyTooLarge(err) {
+					response.WriteError(w, r, errs.ErrBodyTooLarge, "request body is too large")
+					return
+				}
+				response.WriteError(w, r, requestError(r, err), "invalid request format")
+				return
+			}
+		}
+
+		if !v.options.Responses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := newRecorder()
+		next.ServeHTTP(rec, r)
+
+		if err := openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 rec.status,
+			Header:                 rec.header,
+			Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+			Options: &openapi3filter.Options{
+				IncludeResponseStatus: true,
+				// Only JSON bodies are described in detail, e.g. the docs page is HTML
+				ExcludeResponseBody: !strings.HasPrefix(rec.header.Get("Content-Type"), "application/json"),
+			},
+		}); err != nil {
+			logger.FromContext(r.Context()).Error("response does not match the openapi spec",
+				"method", r.Method,
+				"route", route.Path,
+				"error", err,
+			)
+			response.WriteError(w, r, errs.ErrInternalServer, "response does not match the API specification")
+			return
+		}
+
+		rec.flush(w)
+	})
+}
+
+// requestError converts validation failures into field-level details.
+func requestError(r *http.Request, err error) error {
+	var details []errs.FieldError
+	collectFieldErrors(r, err, nil, &details)
+
+	if len(details) == 0 {
+		details = append(details, request.NewFieldError(r, "", "unknown", "", ""))
+	}
+
+	return errs.NewValidationError(details...)
+}
+
+func collectFieldErrors(r *http.Request, err error, reqErr *openapi3filter.RequestError, details *[]errs.FieldError) {
+	var multiErr openapi3.MultiError
+	var nextReqErr *openapi3filter.RequestError
+	var schemaErr *openapi3.SchemaError
+	var parseErr *openapi3filter.ParseError
+
+	switch {
+	case errors.As(err, &multiErr):
+		for _, e := range multiErr {
+			collectFieldErrors(r, e, reqErr, details)
+		}
+
+	case errors.As(err, &nextReqErr) && nextReqErr != reqErr:
+		if nextReqErr.Err != nil {
+			collectFieldErrors(r, nextReqErr.Err, nextReqErr, details)
+			return
+		}
+		*details = append(*details, request.NewFieldError(r, parameterName(nextReqErr), "required", "", ""))
+
+	case errors.As(err, &schemaErr):
+		*details = append(*details, schemaFieldError(r, schemaErr, reqErr))
+
+	case errors.As(err, &parseErr) && reqErr != nil && reqErr.RequestBody != nil:
+		*details = append(*details, request.NewFieldError(r, "", "syntax", "", ""))
+
+	default:
+		*details = append(*details, request.NewFieldError(r, parameterName(reqErr), "format", "", ""))
+	}
+}
+
+// schemaFieldError maps JSON schema keywords onto the rule names used by the request validator.
+func schemaFieldError(r *http.Request, schemaErr *openapi3.SchemaError, reqErr *openapi3filter.RequestError) errs.FieldError {
+	field := fieldPath(schemaErr.JSONPointer())
+	if name := parameterName(reqErr); name != "" {
+		field = name
+	}
+
+	schema := schemaErr.Schema
+	if schema == nil {
+		return request.NewFieldError(r, field, "unknown", "", "")
+	}
+
+	switch schemaErr.SchemaField {
+	case "required":
+		return request.NewFieldError(r, field, "required", "", "")
+	case "minLength":
+		return request.NewFieldError(r, field, "min", strconv.FormatUint(schema.MinLength, 10), request.KindString)
+	case "maxLength":
+		return request.NewFieldError(r, field, "max", formatUint(schema.MaxLength), request.KindString)
+	case "minItems":
+		return request.NewFieldError(r, field, "min", strconv.FormatUint(schema.MinItems, 10), request.KindList)
+	case "maxItems":
+		return request.NewFieldError(r, field, "max", formatUint(schema.MaxItems), request.KindList)
+	case "minimum":
+		return request.NewFieldError(r, field, "min", formatFloat(schema.Min), request.KindNumber)
+	case "maximum":
+		return request.NewFieldError(r, field, "max", formatFloat(schema.Max), request.KindNumber)
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		return request.NewFieldError(r, field, "oneof", strings.Join(values, " "), "")
+	case "format":
+		if schema.Format == "email" || schema.Format == "uuid" {
+			return request.NewFieldError(r, field, schema.Format, "", "")
+		}
+		return request.NewFieldError(r, field, "format", schema.Format, "")
+	case "type":
+		var types []string
+		if schema.Type != nil {
+			types = schema.Type.Slice()
+		}
+		return request.NewFieldError(r, field, "type", strings.Join(types, " "), "")
+	default:
+		return request.NewFieldError(r, field, "unknown", "", "")
+	}
+}
+
+func parameterName(reqErr *openapi3filter.RequestError) string {
+	if reqErr == nil || reqErr.Parameter == nil {
+		return ""
+	}
+	return reqErr.Parameter.Name
+}
+
+// fieldPath renders a JSON pointer the way the request validator does: "scopes[0]".
+func fieldPath(pointer []string) string {
+	var b strings.Builder
+	for _, part := range pointer {
+		if _, err := strconv.Atoi(part); err == nil {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+func formatUint(v *uint64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatUint(*v, 10)
+}
+
+func formatFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+// recorder buffers a response so it can be validated before it is sent.
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+func (rec *recorder) flush(w http.ResponseWriter) {
+	for k, v := range rec.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.status)
+	_, _ = w.Write(rec.body.Bytes())
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	auditdto "github.com/platonso/hrmate/internal/handler/audit/dto"
+	formdto "github.com/platonso/hrmate/internal/handler/form/dto"
+	userdto "github.com/platonso/hrmate/internal/handler/user/dto"
+	"github.com/platonso/hrmate/internal/service/privacy/model"
+)
+
+func ToExportResponse(export *model.Export, locale string) ExportResponse {
+	return ExportResponse{
+		ExportedAt:   export.ExportedAt,
+		Profile:      userdto.ToUserResponse(&export.User),
+		Forms:        formdto.ToFormResponses(export.Forms, locale),
+		Reviews:      ToReviewResponses(export.Reviews),
+		AuditEntries: auditdto.ToAuditEntryResponses(export.AuditEntries),
+	}
+}
+
+func ToReviewResponses(reviews []model.Review) []ReviewResponse {
+	responses := make([]ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = ReviewResponse{
+			FormID:     review.FormID,
+			Status:     string(review.Status),
+			ReviewedAt: review.ReviewedAt,
+			Comment:    review.Comment,
+		}
+	}
+	return responses
+}
+
+func ToErasureResponse(result *model.ErasureResult) ErasureResponse {
+	return ErasureResponse{
+		Forms:        result.Forms,
+		AuditEntries: result.AuditEntries,
+		Records:      result.Records,
+	}
+}
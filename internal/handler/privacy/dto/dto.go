@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	auditdto "github.com/platonso/hrmate/internal/handler/audit/dto"
+	formdto "github.com/platonso/hrmate/internal/handler/form/dto"
+	userdto "github.com/platonso/hrmate/internal/handler/user/dto"
+)
+
+type ExportResponse struct {
+	ExportedAt   time.Time                     `json:"exportedAt"`
+	Profile      userdto.UserResponse          `json:"profile"`
+	Forms        []formdto.FormResponse        `json:"forms"`
+	Reviews      []ReviewResponse              `json:"reviews"`
+	AuditEntries []auditdto.AuditEntryResponse `json:"auditEntries"`
+}
+
+type ReviewResponse struct {
+	FormID     uuid.UUID  `json:"formId"`
+	Status     string     `json:"status"`
+	ReviewedAt *time.Time `json:"reviewedAt"`
+	Comment    *string    `json:"comment"`
+}
+
+type ErasureResponse struct {
+	Forms        int64 `json:"forms"`
+	AuditEntries int64 `json:"auditEntries"`
+	Records      int64 `json:"records"`
+}
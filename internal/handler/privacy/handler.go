@@ -0,0 +1,119 @@
+package privacy
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/middleware"
+	"github.com/platonso/hrmate/internal/handler/privacy/dto"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/i18n"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/service/privacy/model"
+)
+
+type Service interface {
+	ExportData(ctx context.Context, userID uuid.UUID) (*model.Export, error)
+	EraseUser(ctx context.Context, userID uuid.UUID, expectedVersion *int) (*model.ErasureResult, error)
+}
+
+type Handler struct {
+	svc Service
+}
+
+func NewHandler(svc Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+// HandleExport returns the data held about the current user as JSON, or with
+// format=zip as an archive with a file per section.
+func (h *Handler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "zip" {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid export format")
+		return
+	}
+
+	export, err := h.svc.ExportData(r.Context(), userID)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to export data")
+		return
+	}
+
+	resp := dto.ToExportResponse(export, i18n.FromContext(r.Context()))
+	if format != "zip" {
+		response.WriteJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="hrmate-export-%s.zip"`, export.ExportedAt.Format("2006-01-02")))
+	if err := writeArchive(w, &resp); err != nil {
+		logger.FromContext(r.Context()).Error("failed to write export archive", "error", err)
+	}
+}
+
+// HandleErase anonymizes the user, see privacy.Service.EraseUser.
+func (h *Handler) HandleErase(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		response.WriteError(w, r, err, "the user has been modified")
+		return
+	}
+
+	result, err := h.svc.EraseUser(r.Context(), userID, expectedVersion)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to erase user")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, dto.ToErasureResponse(result))
+}
+
+func writeArchive(w http.ResponseWriter, resp *dto.ExportResponse) error {
+	archive := zip.NewWriter(w)
+
+	for _, file := range []struct {
+		name string
+		data any
+	}{
+		{"profile.json", resp.Profile},
+		{"forms.json", resp.Forms},
+		{"reviews.json", resp.Reviews},
+		{"audit.json", resp.AuditEntries},
+	} {
+		f, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: resp.ExportedAt})
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
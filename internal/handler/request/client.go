@@ -0,0 +1,100 @@
+package request
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/platonso/hrmate/internal/domain"
+)
+
+// DeviceHeader lets clients name the device explicitly, e.g. "Anna's iPhone".
+const DeviceHeader = "X-Device-Name"
+
+// ClientInfo extracts the client description used for sessions.
+func ClientInfo(r *http.Request) domain.ClientInfo {
+	userAgent := r.UserAgent()
+
+	device := strings.TrimSpace(r.Header.Get(DeviceHeader))
+	if device == "" {
+		device = describeDevice(userAgent)
+	}
+
+	return domain.ClientInfo{
+		Device:    device,
+		IPAddress: ClientIP(r),
+		UserAgent: userAgent,
+	}
+}
+
+type clientIPKey struct{}
+
+// WithClientIP stores the client address resolved behind trusted proxies.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the client address without the port: the one resolved by
+// middleware.ClientIP when set, otherwise the address of the peer.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return PeerIP(r)
+}
+
+// PeerIP returns the address of the connected peer, which is the reverse proxy
+// when there is one.
+func PeerIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func describeDevice(userAgent string) string {
+	var platform, browser string
+
+	switch {
+	case strings.Contains(userAgent, "iPhone"):
+		platform = "iPhone"
+	case strings.Contains(userAgent, "iPad"):
+		platform = "iPad"
+	case strings.Contains(userAgent, "Android"):
+		platform = "Android"
+	case strings.Contains(userAgent, "Windows"):
+		platform = "Windows"
+	case strings.Contains(userAgent, "Macintosh"):
+		platform = "macOS"
+	case strings.Contains(userAgent, "Linux"):
+		platform = "Linux"
+	}
+
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+
+	switch {
+	case platform != "" && browser != "":
+		return browser + " on " + platform
+	case platform != "":
+		return platform
+	case browser != "":
+		return browser
+	case userAgent != "":
+		// Non-browser clients such as curl/8.5.0
+		name, _, _ := strings.Cut(userAgent, " ")
+		return name
+	default:
+		return "Unknown device"
+	}
+}
@@ -2,22 +2,114 @@ package request
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
+	errs "github.com/platonso/hrmate/internal/errors"
 )
 
-var v = validator.New()
+var v = newValidator()
 
+func newValidator() *validator.Validate {
+	validate := validator.New()
+
+	// Report fields by the names clients send, not by Go struct field names
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return validate
+}
+
+// DecodeAndValidate decodes the JSON body into dest and validates it.
+// Any failure is returned as an *errs.ValidationError, except for a body
+// over the size limit, which is errs.ErrBodyTooLarge.
 func DecodeAndValidate(r *http.Request, dest any) error {
 	if err := json.NewDecoder(r.Body).Decode(dest); err != nil {
-		return fmt.Errorf("decode json: %w", err)
+		if IsBodyTooLarge(err) {
+			return errs.ErrBodyTooLarge
+		}
+		return errs.NewValidationError(decodeFieldError(r, err))
 	}
 
 	if err := v.Struct(dest); err != nil {
-		return fmt.Errorf("request validation: %w", err)
+		var validationErrs validator.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			return errs.NewValidationError(NewFieldError(r, "", "unknown", "", ""))
+		}
+
+		details := make([]errs.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			details = append(details, NewFieldError(r, fieldPath(fe), fe.Tag(), fe.Param(), valueKind(fe.Kind())))
+		}
+		return errs.NewValidationError(details...)
 	}
 
 	return nil
 }
+
+// IsBodyTooLarge reports whether reading the body failed on the limit set by http.MaxBytesReader.
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+func decodeFieldError(r *http.Request, err error) errs.FieldError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return NewFieldError(r, "", "required", "", "")
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return NewFieldError(r, "", "syntax", "", "")
+	case errors.As(err, &typeErr):
+		return NewFieldError(r, typeErr.Field, "type", jsonType(typeErr.Type.Kind()), "")
+	default:
+		// e.g. a malformed date in a time.Time field
+		return NewFieldError(r, "", "format", "", "")
+	}
+}
+
+// fieldPath drops the root struct name from the namespace: "TokenCreateRequest.scopes[0]" -> "scopes[0]".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+func valueKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return KindString
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return KindList
+	default:
+		return KindNumber
+	}
+}
+
+func jsonType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return "number"
+	}
+}
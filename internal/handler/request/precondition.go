@@ -0,0 +1,31 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	errs "github.com/platonso/hrmate/internal/errors"
+)
+
+// IfMatch returns the resource version required by the If-Match header.
+// It returns nil when the header is absent or "*", so any version is accepted.
+// A tag that was not issued by this API can never match and fails with errs.ErrPreconditionFailed.
+func IfMatch(r *http.Request) (*int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return nil, errs.ErrPreconditionFailed
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return nil, errs.ErrPreconditionFailed
+	}
+
+	return &version, nil
+}
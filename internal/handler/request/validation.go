@@ -0,0 +1,37 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/i18n"
+)
+
+// Kinds of values a size rule is applied to, as the wording differs.
+const (
+	KindString = "string"
+	KindList   = "list"
+	KindNumber = "number"
+)
+
+// NewFieldError builds a field error with a message in the locale of the request.
+// kind is only relevant for size rules (min, max) and may be empty otherwise.
+func NewFieldError(r *http.Request, field, rule, param, kind string) errs.FieldError {
+	locale := i18n.FromContext(r.Context())
+
+	key := "validation." + rule + "." + kind
+	if _, ok := i18n.Lookup(locale, key); !ok {
+		key = "validation." + rule
+	}
+	if _, ok := i18n.Lookup(locale, key); !ok {
+		key = "validation.unknown"
+	}
+
+	return errs.FieldError{
+		Field:   field,
+		Rule:    rule,
+		Param:   param,
+		Message: i18n.T(locale, key, "param", strings.ReplaceAll(param, " ", ", ")),
+	}
+}
@@ -3,14 +3,26 @@ package response
 import (
 	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
+	"strings"
 
 	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/i18n"
+	"github.com/platonso/hrmate/internal/logger"
 )
 
 type errorDetail struct {
-	Code    string `json:"code"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Details []fieldDetail `json:"details,omitempty"`
+	// RequestID lets support find the log lines of the failed request
+	RequestID string `json:"requestId,omitempty"`
+}
+
+type fieldDetail struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param"`
 	Message string `json:"message"`
 }
 
@@ -18,7 +30,9 @@ type errorResponse struct {
 	Error errorDetail `json:"error"`
 }
 
-func WriteError(w http.ResponseWriter, err error, msg string) {
+// WriteError writes the error envelope. msg is written in English and translated
+// to the locale of the request when the catalog knows it.
+func WriteError(w http.ResponseWriter, r *http.Request, err error, msg string) {
 	if err == nil {
 		err = errs.ErrInternalServer
 		msg = "unknown error"
@@ -28,26 +42,55 @@ func WriteError(w http.ResponseWriter, err error, msg string) {
 
 	switch {
 	case errors.Is(err, errs.ErrInvalidCredentials),
-		errors.Is(err, errs.ErrUnauthorized):
+		errors.Is(err, errs.ErrUnauthorized),
+		errors.Is(err, errs.ErrInvalidAuthState):
 		statusCode = http.StatusUnauthorized
 
 	case errors.Is(err, errs.ErrUserNotActive),
-		errors.Is(err, errs.ErrForbidden):
+		errors.Is(err, errs.ErrForbidden),
+		errors.Is(err, errs.ErrEmailNotVerified),
+		errors.Is(err, errs.ErrInsufficientScope),
+		errors.Is(err, errs.ErrImpersonationReadOnly):
 		statusCode = http.StatusForbidden
 
 	case errors.Is(err, errs.ErrUserAlreadyExists),
 		errors.Is(err, errs.ErrFormAlreadyApproved),
 		errors.Is(err, errs.ErrFormAlreadyRejected),
-		errors.Is(err, errs.ErrNoAvailableExecutors):
+		errors.Is(err, errs.ErrNoAvailableExecutors),
+		errors.Is(err, errs.ErrLastAdmin),
+		errors.Is(err, errs.ErrUserHasForms),
+		errors.Is(err, errs.ErrLegalHold),
+		errors.Is(err, errs.ErrInvitationsUnavailable),
+		errors.Is(err, errs.ErrDepartmentAlreadyExists),
+		errors.Is(err, errs.ErrIdempotencyKeyInUse):
 		statusCode = http.StatusConflict
 
 	case errors.Is(err, errs.ErrUserNotFound),
-		errors.Is(err, errs.ErrFormNotFound):
+		errors.Is(err, errs.ErrFormNotFound),
+		errors.Is(err, errs.ErrIdentityNotFound),
+		errors.Is(err, errs.ErrTokenNotFound),
+		errors.Is(err, errs.ErrSessionNotFound),
+		errors.Is(err, errs.ErrDepartmentNotFound):
 		statusCode = http.StatusNotFound
 
-	case errors.Is(err, errs.ErrInvalidRequest):
+	case errors.Is(err, errs.ErrInvalidRequest),
+		errors.Is(err, errs.ErrPasswordTooShort),
+		errors.Is(err, errs.ErrPasswordBreached),
+		errors.Is(err, errs.ErrPasswordMatchesEmail):
 		statusCode = http.StatusBadRequest
 
+	case errors.Is(err, errs.ErrPreconditionFailed):
+		statusCode = http.StatusPreconditionFailed
+
+	case errors.Is(err, errs.ErrBodyTooLarge):
+		statusCode = http.StatusRequestEntityTooLarge
+
+	case errors.Is(err, errs.ErrTooManyRequests):
+		statusCode = http.StatusTooManyRequests
+
+	case errors.Is(err, errs.ErrIdempotencyKeyMismatch):
+		statusCode = http.StatusUnprocessableEntity
+
 	default:
 		statusCode = http.StatusInternalServerError
 	}
@@ -57,12 +100,42 @@ func WriteError(w http.ResponseWriter, err error, msg string) {
 
 	errResponse := errorResponse{
 		Error: errorDetail{
-			Code:    err.Error(),
-			Message: msg,
+			Code:      err.Error(),
+			Message:   localize(i18n.FromContext(r.Context()), err.Error(), msg),
+			RequestID: logger.RequestID(r.Context()),
 		},
 	}
 
+	var validationErr *errs.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, d := range validationErr.Details {
+			errResponse.Error.Details = append(errResponse.Error.Details, fieldDetail{
+				Field:   d.Field,
+				Rule:    d.Rule,
+				Param:   d.Param,
+				Message: d.Message,
+			})
+		}
+	}
+
 	if err := json.NewEncoder(w).Encode(errResponse); err != nil {
-		log.Printf("Failed to encode error response: %v", err)
+		logger.FromContext(r.Context()).Error("failed to encode error response", "error", err)
+	}
+}
+
+// localize translates the handler message, falling back to the generic description
+// of the error code for messages missing from the catalog.
+func localize(locale, code, msg string) string {
+	if translated, ok := i18n.Lookup(locale, "message."+msg); ok {
+		return translated
+	}
+
+	if base, _, _ := strings.Cut(locale, "-"); base == i18n.DefaultLocale {
+		return msg
+	}
+
+	if translated, ok := i18n.Lookup(locale, "error."+code); ok {
+		return translated
 	}
+	return msg
 }
@@ -0,0 +1,12 @@
+package response
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// SetETag announces the version of the returned resource. Clients send it back
+// in If-Match to make sure they do not overwrite someone else's changes.
+func SetETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
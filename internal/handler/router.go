@@ -1,14 +1,26 @@
 package handler
 
 import (
+	"log/slog"
 	"net/http"
+	"net/netip"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/handler/audit"
 	"github.com/platonso/hrmate/internal/handler/auth"
 	"github.com/platonso/hrmate/internal/handler/form"
+	"github.com/platonso/hrmate/internal/handler/graphql"
+	"github.com/platonso/hrmate/internal/handler/health"
 	"github.com/platonso/hrmate/internal/handler/middleware"
+	"github.com/platonso/hrmate/internal/handler/openapi"
+	"github.com/platonso/hrmate/internal/handler/privacy"
+	"github.com/platonso/hrmate/internal/handler/scim"
+	"github.com/platonso/hrmate/internal/handler/session"
+	"github.com/platonso/hrmate/internal/handler/sso"
+	"github.com/platonso/hrmate/internal/handler/token"
 	"github.com/platonso/hrmate/internal/handler/user"
 )
 
@@ -19,59 +31,318 @@ type AuthProvider interface {
 
 type UserProvider interface {
 	user.Service
+	scim.UserService
 	middleware.UserService
 }
 
+type TokenProvider interface {
+	token.Service
+	middleware.TokenService
+}
+
+type SessionProvider interface {
+	session.Service
+	middleware.SessionService
+}
+
+type AuditProvider interface {
+	audit.Service
+	middleware.AuditService
+}
+
+type SSOProvider interface {
+	sso.Service
+}
+
+type Services struct {
+	Auth    AuthProvider
+	User    UserProvider
+	Form    form.Service
+	Token   TokenProvider
+	Session SessionProvider
+	Audit   AuditProvider
+	Health  health.Service
+	Privacy privacy.Service
+	// Department backs the groups of SCIM provisioning
+	Department scim.DepartmentService
+
+	Idempotency middleware.IdempotencyService
+	RateLimit   middleware.RateLimitService
+	// SSO is nil when single sign-on is not configured
+	SSO SSOProvider
+}
+
+// APIv1Prefix is where the first version of the API is mounted
+const APIv1Prefix = "/api/v1"
+
+// LegacyOptions controls the unversioned aliases of the v1 routes, e.g. /forms.
+type LegacyOptions struct {
+	Enabled      bool
+	DeprecatedAt time.Time
+	Sunset       time.Time
+}
+
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowCredentials bool
+}
+
+type Options struct {
+	AllowImpersonatedWrites bool
+
+	CORS         CORSOptions
+	Legacy       LegacyOptions
+	HSTSMaxAge   time.Duration
+	MaxBodyBytes int64
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For and X-Real-IP are believed
+	TrustedProxies []netip.Prefix
+
+	Logger  *slog.Logger
+	Metrics middleware.MetricsRecorder
+	// MetricsHandler serves /metrics on the API port, nil when disabled or served separately
+	MetricsHandler http.Handler
+
+	Docs      *openapi.Handler
+	Validator *openapi.Validator
+	GraphQL   *graphql.Handler
+}
+
 type Router struct {
-	handlerAuth *auth.Handler
-	handlerUser *user.Handler
-	handlerForm *form.Handler
-	middleware  *middleware.Auth
+	handlerAuth    *auth.Handler
+	handlerUser    *user.Handler
+	handlerForm    *form.Handler
+	handlerHealth  *health.Handler
+	handlerSSO     *sso.Handler
+	handlerToken   *token.Handler
+	handlerSession *session.Handler
+	handlerAudit   *audit.Handler
+	handlerPrivacy *privacy.Handler
+	handlerDocs    *openapi.Handler
+	handlerGraphQL *graphql.Handler
+	handlerSCIM    *scim.Handler
+	middleware     *middleware.Auth
+	audit          *middleware.Audit
+	accessLog      *middleware.AccessLog
+	metrics        *middleware.Metrics
+	handlerMetrics http.Handler
+	idempotency    *middleware.Idempotency
+	rateLimit      *middleware.RateLimit
+	security       *middleware.SecurityHeaders
+	clientIP       *middleware.ClientIP
+	validator      *openapi.Validator
+	cors           CORSOptions
+	legacy         LegacyOptions
+	maxBodyBytes   int64
 }
 
-func NewRouter(authSvc AuthProvider, userSvc UserProvider, formSvc form.Service,
-) *Router {
+func NewRouter(svcs Services, opts Options) *Router {
 	authMiddleware := &middleware.Auth{
-		AuthSvc: authSvc,
-		UserSvc: userSvc,
+		AuthSvc:    svcs.Auth,
+		UserSvc:    svcs.User,
+		TokenSvc:   svcs.Token,
+		SessionSvc: svcs.Session,
+
+		AllowImpersonatedWrites: opts.AllowImpersonatedWrites,
 	}
 
-	return &Router{
-		handlerAuth: auth.NewHandler(authSvc),
-		handlerUser: user.NewHandler(userSvc),
-		handlerForm: form.NewHandler(formSvc),
-		middleware:  authMiddleware,
+	router := &Router{
+		handlerAuth:    auth.NewHandler(svcs.Auth),
+		handlerUser:    user.NewHandler(svcs.User),
+		handlerForm:    form.NewHandler(svcs.Form),
+		handlerHealth:  health.NewHandler(svcs.Health),
+		handlerToken:   token.NewHandler(svcs.Token),
+		handlerSession: session.NewHandler(svcs.Session),
+		handlerAudit:   audit.NewHandler(svcs.Audit),
+		handlerPrivacy: privacy.NewHandler(svcs.Privacy),
+		handlerDocs:    opts.Docs,
+		handlerGraphQL: opts.GraphQL,
+		handlerSCIM:    scim.NewHandler(svcs.User, svcs.Department),
+		middleware:     authMiddleware,
+		audit:          &middleware.Audit{AuditSvc: svcs.Audit},
+		accessLog:      &middleware.AccessLog{Logger: opts.Logger},
+		metrics:        &middleware.Metrics{Recorder: opts.Metrics},
+		handlerMetrics: opts.MetricsHandler,
+		idempotency:    &middleware.Idempotency{IdempotencySvc: svcs.Idempotency},
+		rateLimit:      &middleware.RateLimit{RateLimitSvc: svcs.RateLimit},
+		security:       &middleware.SecurityHeaders{HSTSMaxAge: opts.HSTSMaxAge},
+		clientIP:       &middleware.ClientIP{TrustedProxies: opts.TrustedProxies},
+		validator:      opts.Validator,
+		cors:           opts.CORS,
+		legacy:         opts.Legacy,
+		maxBodyBytes:   opts.MaxBodyBytes,
 	}
+
+	if svcs.SSO != nil {
+		router.handlerSSO = sso.NewHandler(svcs.SSO)
+	}
+
+	return router
 }
 
-func (rt *Router) Routes() http.Handler {
+func (rt *Router) Routes() chi.Router {
 	r := chi.NewRouter()
 
+	r.Use(rt.clientIP.Handle)
+	r.Use(middleware.RequestID)
+	r.Use(rt.accessLog.Handle)
+	r.Use(middleware.Tracing)
+	r.Use(rt.metrics.Handle)
+	r.Use(rt.security.Handle)
+
 	// CORS middleware ==================================================================
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"}, // Allow all origins for testing
-		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: false,
+		AllowedOrigins: rt.cors.AllowedOrigins,
+		AllowedMethods: rt.cors.AllowedMethods,
+		AllowedHeaders: []string{"Accept", "Accept-Language", "Authorization", "Content-Type", "X-Device-Name", "If-Match", middleware.IdempotencyKeyHeader, middleware.RequestIDHeader, "traceparent", "tracestate"},
+		ExposedHeaders: []string{
+			"Link",
+			"Content-Language",
+			"ETag",
+			middleware.RequestIDHeader,
+			middleware.ImpersonatedByHeader,
+			middleware.ImpersonatedUserHeader,
+			middleware.IdempotentReplayedHeader,
+			middleware.RateLimitLimitHeader,
+			middleware.RateLimitRemainingHeader,
+			middleware.RateLimitResetHeader,
+			"Retry-After",
+			middleware.DeprecationHeader,
+			middleware.SunsetHeader,
+		},
+		AllowCredentials: rt.cors.AllowCredentials,
 		MaxAge:           300,
 	}))
 	// ===================================================================================
 
-	// Authentication
-	r.Post("/register", rt.handlerAuth.HandleRegister)
-	r.Post("/login", rt.handlerAuth.HandleLogin)
+	if rt.legacy.Enabled {
+		r.Use((&middleware.LegacyRoutes{
+			Routes:       r,
+			Prefix:       APIv1Prefix,
+			DeprecatedAt: rt.legacy.DeprecatedAt,
+			Sunset:       rt.legacy.Sunset,
+		}).Handle)
+	}
+
+	r.Use(middleware.MaxBodySize(rt.maxBodyBytes))
+
+	r.Use(middleware.Locale)
+	r.Use(rt.validator.Middleware)
+
+	// API documentation
+	r.Get("/openapi.json", rt.handlerDocs.HandleSpec)
+	r.Get("/docs", rt.handlerDocs.HandleDocs)
+
+	// Monitoring
+	r.Get("/healthz", rt.handlerHealth.HandleLiveness)
+	r.Get("/readyz", rt.handlerHealth.HandleReadiness)
+	if rt.handlerMetrics != nil {
+		r.Method(http.MethodGet, "/metrics", rt.handlerMetrics)
+	}
+
+	r.Route(APIv1Prefix, rt.v1)
+	r.Route("/scim/v2", rt.scim)
+
+	return r
+}
+
+// scim registers SCIM 2.0 provisioning. The paths are fixed by the standard, so they
+// are not versioned with the API. Identity providers authenticate with an API token
+// of an administrator that has the scim scope.
+func (rt *Router) scim(r chi.Router) {
+	r.Use(
+		rt.middleware.AuthMiddleware,
+		rt.rateLimit.Limit(domain.RateLimitGroupAdmin),
+		rt.middleware.RequireRoles(domain.RoleAdmin),
+		rt.middleware.RequireActiveStatus,
+		rt.middleware.RequireScopes(domain.ScopeSCIM),
+		rt.audit.Record,
+	)
+
+	r.Get("/ServiceProviderConfig", rt.handlerSCIM.HandleServiceProviderConfig)
+	r.Get("/ResourceTypes", rt.handlerSCIM.HandleResourceTypes)
+
+	r.Get("/Users", rt.handlerSCIM.HandleGetUsers)
+	r.Post("/Users", rt.handlerSCIM.HandleCreateUser)
+	r.Get("/Users/{id}", rt.handlerSCIM.HandleGetUser)
+	r.Put("/Users/{id}", rt.handlerSCIM.HandleReplaceUser)
+	r.Patch("/Users/{id}", rt.handlerSCIM.HandlePatchUser)
+	r.Delete("/Users/{id}", rt.handlerSCIM.HandleDeleteUser)
+
+	r.Get("/Groups", rt.handlerSCIM.HandleGetGroups)
+	r.Post("/Groups", rt.handlerSCIM.HandleCreateGroup)
+	r.Get("/Groups/{id}", rt.handlerSCIM.HandleGetGroup)
+	r.Put("/Groups/{id}", rt.handlerSCIM.HandleReplaceGroup)
+	r.Patch("/Groups/{id}", rt.handlerSCIM.HandlePatchGroup)
+	r.Delete("/Groups/{id}", rt.handlerSCIM.HandleDeleteGroup)
+}
+
+// v1 registers the routes of the first API version. A later version gets a method
+// of its own, registering the handlers whose DTOs changed next to the shared ones.
+func (rt *Router) v1(r chi.Router) {
+	r.With(rt.rateLimit.Limit(domain.RateLimitGroupAuth)).Group(func(r chi.Router) {
+		// Authentication
+		r.Post("/register", rt.handlerAuth.HandleRegister)
+		r.Post("/login", rt.handlerAuth.HandleLogin)
+
+		// Single sign-on
+		if rt.handlerSSO != nil {
+			r.Get("/auth/oidc/login", rt.handlerSSO.HandleLogin)
+			r.Get("/auth/oidc/callback", rt.handlerSSO.HandleCallback)
+		}
+	})
 
 	// Employee
 	r.Route("/forms", func(r chi.Router) {
 		r.With(
 			rt.middleware.AuthMiddleware,
+			rt.rateLimit.Limit(domain.RateLimitGroupEmployee),
 			rt.middleware.RequireRoles(domain.RoleEmployee),
 			rt.middleware.RequireActiveStatus,
+			rt.audit.Record,
+			rt.idempotency.Handle,
+		).Group(func(r chi.Router) {
+			r.With(rt.middleware.RequireScopes(domain.ScopeFormsWrite)).Post("/", rt.handlerForm.HandleCreateForm)
+			r.With(rt.middleware.RequireScopes(domain.ScopeFormsRead)).Get("/", rt.handlerForm.HandleGetForms)
+			r.With(rt.middleware.RequireScopes(domain.ScopeFormsRead)).Get("/{id}", rt.handlerForm.HandleGetForm)
+		})
+	})
+
+	// Read-only queries for dashboards, available to every role
+	r.With(
+		middleware.ReadOnly,
+		rt.middleware.AuthMiddleware,
+		rt.rateLimit.LimitByRole,
+		rt.middleware.RequireActiveStatus,
+	).Group(func(r chi.Router) {
+		r.Get("/graphql", rt.handlerGraphQL.HandleQuery)
+		r.Post("/graphql", rt.handlerGraphQL.HandleQuery)
+	})
+
+	// Personal settings, access tokens and sessions
+	r.Route("/me", func(r chi.Router) {
+		r.With(
+			rt.middleware.AuthMiddleware,
+			rt.rateLimit.Limit(domain.RateLimitGroupEmployee),
+			rt.middleware.RejectAPITokens,
+			rt.middleware.RequireActiveStatus,
+			rt.audit.Record,
+			rt.idempotency.Handle,
 		).Group(func(r chi.Router) {
-			r.Post("/", rt.handlerForm.HandleCreateForm)
-			r.Get("/", rt.handlerForm.HandleGetForms)
-			r.Get("/{id}", rt.handlerForm.HandleGetForm)
+			r.Get("/tokens", rt.handlerToken.HandleGetTokens)
+			r.Get("/sessions", rt.handlerSession.HandleGetSessions)
+
+			// Credentials are managed by the user alone, never by an admin impersonating them
+			r.With(rt.middleware.RejectImpersonation).Group(func(r chi.Router) {
+				r.Post("/tokens", rt.handlerToken.HandleCreateToken)
+				r.Delete("/tokens/{tokenId}", rt.handlerToken.HandleRevokeToken)
+				r.Delete("/sessions/{id}", rt.handlerSession.HandleRevokeSession)
+				r.With(rt.rateLimit.Limit(domain.RateLimitGroupAuth)).Put("/password", rt.handlerAuth.HandleChangePassword)
+			})
+
+			r.Put("/locale", rt.handlerUser.HandleChangeLocale)
+
+			r.Get("/export", rt.handlerPrivacy.HandleExport)
 		})
 	})
 
@@ -79,15 +350,18 @@ func (rt *Router) Routes() http.Handler {
 	r.Route("/hr", func(r chi.Router) {
 		r.With(
 			rt.middleware.AuthMiddleware,
+			rt.rateLimit.Limit(domain.RateLimitGroupHR),
 			rt.middleware.RequireRoles(domain.RoleHR),
 			rt.middleware.RequireActiveStatus,
+			rt.audit.Record,
+			rt.idempotency.Handle,
 		).Group(func(r chi.Router) {
-			r.Get("/users", rt.handlerUser.HandleGetUsers)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersRead)).Get("/users", rt.handlerUser.HandleGetUsers)
 
-			r.Get("/forms", rt.handlerForm.HandleGetFormsWithUsers)
-			r.Get("/forms/{id}", rt.handlerForm.HandleGetForm)
-			r.Patch("/forms/{id}/approve", rt.handlerForm.HandleApprove)
-			r.Patch("/forms/{id}/reject", rt.handlerForm.HandleReject)
+			r.With(rt.middleware.RequireScopes(domain.ScopeFormsRead)).Get("/forms", rt.handlerForm.HandleGetFormsWithUsers)
+			r.With(rt.middleware.RequireScopes(domain.ScopeFormsRead)).Get("/forms/{id}", rt.handlerForm.HandleGetForm)
+			r.With(rt.middleware.RequireScopes(domain.ScopeFormsReview)).Patch("/forms/{id}/approve", rt.handlerForm.HandleApprove)
+			r.With(rt.middleware.RequireScopes(domain.ScopeFormsReview)).Patch("/forms/{id}/reject", rt.handlerForm.HandleReject)
 		})
 	})
 
@@ -95,14 +369,35 @@ func (rt *Router) Routes() http.Handler {
 	r.Route("/admin", func(r chi.Router) {
 		r.With(
 			rt.middleware.AuthMiddleware,
+			rt.rateLimit.Limit(domain.RateLimitGroupAdmin),
 			rt.middleware.RequireRoles(domain.RoleAdmin),
 			rt.middleware.RequireActiveStatus,
+			rt.audit.Record,
+			rt.idempotency.Handle,
 		).Group(func(r chi.Router) {
-			r.Get("/users", rt.handlerUser.HandleGetUsers)
-			r.Patch("/users/{id}/activate", rt.handlerUser.HandleActivate)
-			r.Patch("/users/{id}/deactivate", rt.handlerUser.HandleDeactivate)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersRead)).Get("/users", rt.handlerUser.HandleGetUsers)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Post("/users", rt.handlerUser.HandleCreateUser)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Post("/users/import", rt.handlerUser.HandleImportUsers)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersRead)).Get("/users/export", rt.handlerUser.HandleExportUsers)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersRead)).Get("/users/{id}", rt.handlerUser.HandleGetUser)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Patch("/users/{id}", rt.handlerUser.HandleUpdateUser)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Delete("/users/{id}", rt.handlerUser.HandleDeleteUser)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Put("/users/{id}/role", rt.handlerUser.HandleChangeRole)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Patch("/users/{id}/activate", rt.handlerUser.HandleActivate)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Patch("/users/{id}/deactivate", rt.handlerUser.HandleDeactivate)
+			r.With(rt.middleware.RequireScopes(domain.ScopeUsersWrite)).Delete("/users/{id}/sessions", rt.handlerSession.HandleRevokeUserSessions)
+
+			r.With(rt.middleware.RejectAPITokens).Group(func(r chi.Router) {
+				r.Post("/users/{id}/impersonate", rt.handlerAuth.HandleImpersonate)
+				r.Post("/users/{id}/erase", rt.handlerPrivacy.HandleErase)
+				r.Get("/audit-log", rt.handlerAudit.HandleGetEntries)
+
+				r.Get("/service-accounts", rt.handlerToken.HandleGetServiceAccounts)
+				r.Post("/service-accounts", rt.handlerToken.HandleCreateServiceAccount)
+				r.Get("/service-accounts/{id}/tokens", rt.handlerToken.HandleGetServiceAccountTokens)
+				r.Post("/service-accounts/{id}/tokens", rt.handlerToken.HandleCreateServiceAccountToken)
+				r.Delete("/service-accounts/{id}/tokens/{tokenId}", rt.handlerToken.HandleRevokeServiceAccountToken)
+			})
 		})
 	})
-
-	return r
 }
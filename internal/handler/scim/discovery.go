@@ -0,0 +1,107 @@
+package scim
+
+import "net/http"
+
+const (
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type bulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type filterConfig struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type serviceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulkConfig             `json:"bulk"`
+	Filter                filterConfig           `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	Meta                  meta                   `json:"meta"`
+}
+
+type schemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+type resourceType struct {
+	Schemas          []string          `json:"schemas"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Endpoint         string            `json:"endpoint"`
+	Schema           string            `json:"schema"`
+	SchemaExtensions []schemaExtension `json:"schemaExtensions,omitempty"`
+	Meta             meta              `json:"meta"`
+}
+
+// HandleServiceProviderConfig describes what the API supports (RFC 7643, section 5).
+func (h *Handler) HandleServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	base := baseURL(r)
+	writeJSON(w, r, http.StatusOK, serviceProviderConfig{
+		Schemas: []string{schemaServiceProviderConfig},
+		Patch:   supported{Supported: true},
+		Bulk:    bulkConfig{},
+		Filter:  filterConfig{Supported: true, MaxResults: maxCount},
+		ETag:    supported{Supported: true},
+		AuthenticationSchemes: []authenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "API token",
+			Description: "An API token of an administrator with the scim scope, sent as a bearer token",
+			Primary:     true,
+		}},
+		Meta: meta{ResourceType: "ServiceProviderConfig", Location: base + "/ServiceProviderConfig"},
+	})
+}
+
+func (h *Handler) HandleResourceTypes(w http.ResponseWriter, r *http.Request) {
+	base := baseURL(r)
+	resources := []any{
+		resourceType{
+			Schemas:          []string{schemaResourceType},
+			ID:               "User",
+			Name:             "User",
+			Endpoint:         "/Users",
+			Schema:           schemaUser,
+			SchemaExtensions: []schemaExtension{{Schema: schemaEnterpriseUser}},
+			Meta:             meta{ResourceType: "ResourceType", Location: base + "/ResourceTypes/User"},
+		},
+		resourceType{
+			Schemas:  []string{schemaResourceType},
+			ID:       "Group",
+			Name:     "Group",
+			Endpoint: "/Groups",
+			Schema:   schemaGroup,
+			Meta:     meta{ResourceType: "ResourceType", Location: base + "/ResourceTypes/Group"},
+		},
+	}
+
+	writeJSON(w, r, http.StatusOK, listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
@@ -0,0 +1,390 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// filterExpr is a parsed filter (RFC 7644, section 3.4.2.2), matched against a
+// resource in its JSON form. Attribute names and string values are compared
+// case-insensitively, as none of the supported attributes is case-exact.
+type filterExpr interface {
+	match(resource map[string]any) bool
+}
+
+type (
+	andExpr struct{ left, right filterExpr }
+	orExpr  struct{ left, right filterExpr }
+	notExpr struct{ expr filterExpr }
+
+	presentExpr struct{ path string }
+
+	compareExpr struct {
+		path  string
+		op    string
+		value any
+	}
+
+	// valuePathExpr matches a multi-valued attribute with an element matching the filter,
+	// e.g. emails[type eq "work"]
+	valuePathExpr struct {
+		path   string
+		filter filterExpr
+	}
+)
+
+func (e andExpr) match(res map[string]any) bool { return e.left.match(res) && e.right.match(res) }
+func (e orExpr) match(res map[string]any) bool  { return e.left.match(res) || e.right.match(res) }
+func (e notExpr) match(res map[string]any) bool { return !e.expr.match(res) }
+
+func (e presentExpr) match(res map[string]any) bool {
+	for _, v := range resolve(res, e.path) {
+		if v != nil && v != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (e compareExpr) match(res map[string]any) bool {
+	values := resolve(res, e.path)
+	if e.op == "ne" {
+		return !compareExpr{path: e.path, op: "eq", value: e.value}.match(res)
+	}
+
+	for _, v := range values {
+		// A multi-valued attribute is compared by its value, e.g. emails co "@example.com"
+		if m, ok := v.(map[string]any); ok {
+			v = m[lookupKey(m, "value")]
+		}
+		if compare(v, e.op, e.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e valuePathExpr) match(res map[string]any) bool {
+	for _, v := range resolve(res, e.path) {
+		if m, ok := v.(map[string]any); ok && e.filter.match(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func compare(actual any, op string, expected any) bool {
+	switch want := expected.(type) {
+	case string:
+		got, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		got, want = strings.ToLower(got), strings.ToLower(want)
+
+		switch op {
+		case "eq":
+			return got == want
+		case "co":
+			return strings.Contains(got, want)
+		case "sw":
+			return strings.HasPrefix(got, want)
+		case "ew":
+			return strings.HasSuffix(got, want)
+		case "gt":
+			return got > want
+		case "ge":
+			return got >= want
+		case "lt":
+			return got < want
+		case "le":
+			return got <= want
+		}
+
+	case bool:
+		got, ok := toBool(actual)
+		return ok && op == "eq" && got == want
+
+	case float64:
+		got, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return got == want
+		case "gt":
+			return got > want
+		case "ge":
+			return got >= want
+		case "lt":
+			return got < want
+		case "le":
+			return got <= want
+		}
+
+	case nil:
+		return op == "eq" && actual == nil
+	}
+	return false
+}
+
+// parseFilter parses expressions such as
+//
+//	userName eq "ann@example.com" and (active eq true or not(title pr))
+//	emails[type eq "work" and value co "@example.com"]
+func parseFilter(filter string) (filterExpr, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpen
+	tokenClose
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, text: "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, text: "]"})
+			i++
+
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+
+			var value string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &value); err != nil {
+				return nil, fmt.Errorf("invalid string %s", s[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value})
+			i = end + 1
+
+		default:
+			end := i
+			for end < len(s) && !unicode.IsSpace(rune(s[end])) && !strings.ContainsRune("()[]\"", rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: s[i:end]})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *filterParser) next() (token, error) {
+	t, ok := p.peek()
+	if !ok {
+		return token{}, fmt.Errorf("unexpected end of filter")
+	}
+	p.pos++
+	return t, nil
+}
+
+func (p *filterParser) expect(kind tokenKind, text string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != kind {
+		return fmt.Errorf("expected %q, got %q", text, t.text)
+	}
+	return nil
+}
+
+// isKeyword reports whether the next token is the logical operator
+func (p *filterParser) isKeyword(keyword string) bool {
+	t, ok := p.peek()
+	return ok && t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	if p.isKeyword("not") {
+		p.pos++
+		expr, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	}
+
+	if t, ok := p.peek(); ok && t.kind == tokenOpen {
+		return p.parseGroup()
+	}
+
+	return p.parseAttribute()
+}
+
+func (p *filterParser) parseGroup() (filterExpr, error) {
+	if err := p.expect(tokenOpen, "("); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokenClose, ")"); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func (p *filterParser) parseAttribute() (filterExpr, error) {
+	attr, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if attr.kind != tokenWord {
+		return nil, fmt.Errorf("expected an attribute, got %q", attr.text)
+	}
+
+	if t, ok := p.peek(); ok && t.kind == tokenOpenBracket {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, "]"); err != nil {
+			return nil, err
+		}
+		return valuePathExpr{path: attr.text, filter: inner}, nil
+	}
+
+	opToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToLower(opToken.text)
+
+	switch op {
+	case "pr":
+		return presentExpr{path: attr.text}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("unknown operator %q", opToken.text)
+	}
+
+	valueToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	switch {
+	case valueToken.kind == tokenString:
+		value = valueToken.text
+	case valueToken.kind != tokenWord:
+		return nil, fmt.Errorf("expected a value, got %q", valueToken.text)
+	case strings.EqualFold(valueToken.text, "true"):
+		value = true
+	case strings.EqualFold(valueToken.text, "false"):
+		value = false
+	case strings.EqualFold(valueToken.text, "null"):
+		value = nil
+	default:
+		number, err := strconv.ParseFloat(valueToken.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", valueToken.text)
+		}
+		value = number
+	}
+
+	return compareExpr{path: attr.text, op: op, value: value}, nil
+}
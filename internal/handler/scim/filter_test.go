@@ -0,0 +1,209 @@
+package scim
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFilterPrecedence(t *testing.T) {
+	a := compareExpr{path: "a", op: "eq", value: "1"}
+	b := compareExpr{path: "b", op: "eq", value: "2"}
+	c := compareExpr{path: "c", op: "eq", value: "3"}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   filterExpr
+	}{
+		{
+			name:   "and binds tighter than or",
+			filter: `a eq "1" or b eq "2" and c eq "3"`,
+			want:   orExpr{left: a, right: andExpr{left: b, right: c}},
+		},
+		{
+			name:   "and before or",
+			filter: `a eq "1" and b eq "2" or c eq "3"`,
+			want:   orExpr{left: andExpr{left: a, right: b}, right: c},
+		},
+		{
+			name:   "parentheses group",
+			filter: `a eq "1" and (b eq "2" or c eq "3")`,
+			want:   andExpr{left: a, right: orExpr{left: b, right: c}},
+		},
+		{
+			name:   "operators are left-associative",
+			filter: `a eq "1" or b eq "2" or c eq "3"`,
+			want:   orExpr{left: orExpr{left: a, right: b}, right: c},
+		},
+		{
+			name:   "not applies to its group only",
+			filter: `not (a eq "1") and b eq "2"`,
+			want:   andExpr{left: notExpr{expr: a}, right: b},
+		},
+		{
+			name:   "not of a disjunction",
+			filter: `not (a eq "1" or b eq "2")`,
+			want:   notExpr{expr: orExpr{left: a, right: b}},
+		},
+		{
+			name:   "keywords are case-insensitive",
+			filter: `a EQ "1" OR NOT (b eq "2") AND c eq "3"`,
+			want:   orExpr{left: a, right: andExpr{left: notExpr{expr: b}, right: c}},
+		},
+		{
+			name:   "value path",
+			filter: `emails[type eq "work" and value co "@example.com"]`,
+			want: valuePathExpr{path: "emails", filter: andExpr{
+				left:  compareExpr{path: "type", op: "eq", value: "work"},
+				right: compareExpr{path: "value", op: "co", value: "@example.com"},
+			}},
+		},
+		{
+			name:   "values other than strings",
+			filter: `active eq true and manager eq null or age gt 30 or title pr`,
+			want: orExpr{
+				left: orExpr{
+					left: andExpr{
+						left:  compareExpr{path: "active", op: "eq", value: true},
+						right: compareExpr{path: "manager", op: "eq", value: nil},
+					},
+					right: compareExpr{path: "age", op: "gt", value: float64(30)},
+				},
+				right: presentExpr{path: "title"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseFilter(%q): %v", tt.filter, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFilter(%q) = %#v, want %#v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterStrings(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{name: "escaped quote", filter: `title eq "the \"boss\""`, want: `the "boss"`},
+		{name: "escaped backslash", filter: `title eq "a\\b"`, want: `a\b`},
+		{name: "backslash before the closing quote", filter: `title eq "a\\"`, want: `a\`},
+		{name: "unicode escape", filter: `title eq "\u00e9t\u00e9"`, want: "été"},
+		{name: "brackets and parentheses", filter: `title eq "(a) [b] and c"`, want: "(a) [b] and c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseFilter(%q): %v", tt.filter, err)
+			}
+			want := compareExpr{path: "title", op: "eq", value: tt.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("parseFilter(%q) = %#v, want %#v", tt.filter, got, want)
+			}
+		})
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	resource := map[string]any{
+		"userName": "Ann@Example.com",
+		"title":    "Engineer",
+		"active":   true,
+		"name":     map[string]any{"givenName": "Ann", "familyName": "Lee"},
+		"emails": []any{
+			map[string]any{"value": "ann@example.com", "type": "work", "primary": true},
+			map[string]any{"value": "ann@home.example", "type": "home"},
+		},
+		schemaEnterpriseUser: map[string]any{"department": "Sales"},
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: `userName eq "ann@example.com"`, want: true},
+		{filter: `USERNAME EQ "ANN@EXAMPLE.COM"`, want: true},
+		{filter: `username ne "ann@example.com"`, want: false},
+		{filter: `name.GivenName sw "a"`, want: true},
+		{filter: `urn:ietf:params:scim:schemas:core:2.0:User:title ew "NEER"`, want: true},
+		{filter: schemaEnterpriseUser + `:Department eq "sales"`, want: true},
+		{filter: `emails co "@home.example"`, want: true},
+		{filter: `emails.value eq "ann@home.example"`, want: true},
+		{filter: `emails[type eq "work" and value eq "ann@home.example"]`, want: false},
+		{filter: `Emails[Type eq "HOME"]`, want: true},
+		{filter: `active eq true and not (title pr)`, want: false},
+		{filter: `active eq false or title pr`, want: true},
+		{filter: `nickName pr`, want: false},
+		{filter: `not (nickName pr)`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filter, err := parseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseFilter(%q): %v", tt.filter, err)
+			}
+			if got := filter.match(resource); got != tt.want {
+				t.Errorf("match(%q) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	tests := []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName like "ann"`,
+		`userName eq "ann`,
+		`userName eq ann`,
+		`userName eq "ann" and`,
+		`userName eq "ann" extra`,
+		`(userName eq "ann"`,
+		`userName eq "ann")`,
+		`not userName eq "ann"`,
+		`emails[type eq "work"`,
+		`emails[type eq "work")`,
+		`"userName" eq "ann"`,
+		`title eq "\x"`,
+	}
+
+	for _, filter := range tests {
+		t.Run(filter, func(t *testing.T) {
+			if _, err := parseFilter(filter); err == nil {
+				t.Fatalf("parseFilter(%q) succeeded, want an error", filter)
+			}
+		})
+	}
+}
+
+func TestWriteInvalidFilter(t *testing.T) {
+	_, err := parseFilter(`userName eq`)
+	if err == nil {
+		t.Fatal("parseFilter succeeded, want an error")
+	}
+
+	w := httptest.NewRecorder()
+	writeInvalidFilter(w, httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil), err)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if body := w.Body.String(); !strings.Contains(body, `"scimType":"invalidFilter"`) {
+		t.Errorf("body = %s, want scimType invalidFilter", body)
+	}
+}
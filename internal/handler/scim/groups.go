@@ -0,0 +1,225 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+)
+
+func (h *Handler) HandleGetGroups(w http.ResponseWriter, r *http.Request) {
+	query, err := parseListQuery(r)
+	if err != nil {
+		writeError(w, r, err, "startIndex and count must be integers")
+		return
+	}
+
+	var filter filterExpr
+	if query.filter != "" {
+		if filter, err = parseFilter(query.filter); err != nil {
+			writeInvalidFilter(w, r, err)
+			return
+		}
+	}
+
+	departments, err := h.departments.GetDepartments(r.Context())
+	if err != nil {
+		writeError(w, r, err, "failed to get groups")
+		return
+	}
+
+	// Providers leave out the members when they only look a group up by name
+	withoutMembers := excludesMembers(r)
+
+	base := baseURL(r)
+	resources := make([]any, 0, len(departments))
+	for i := range departments {
+		res := toGroupResource(&departments[i], base)
+		if filter != nil {
+			m, err := toMap(res)
+			if err != nil {
+				logger.FromContext(r.Context()).Error("failed to convert SCIM group", "error", err)
+				writeError(w, r, errs.ErrInternalServer, "failed to get groups")
+				return
+			}
+			if !filter.match(m) {
+				continue
+			}
+		}
+		if withoutMembers {
+			res.Members = nil
+		}
+		resources = append(resources, res)
+	}
+
+	writeJSON(w, r, http.StatusOK, query.page(resources))
+}
+
+func (h *Handler) HandleGetGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errs.ErrDepartmentNotFound, "group not found")
+		return
+	}
+
+	department, err := h.departments.GetDepartment(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "group not found")
+		return
+	}
+
+	res := toGroupResource(department, baseURL(r))
+	if excludesMembers(r) {
+		res.Members = nil
+	}
+	writeJSON(w, r, http.StatusOK, res)
+}
+
+// HandleCreateGroup creates a department; its members leave their previous departments.
+func (h *Handler) HandleCreateGroup(w http.ResponseWriter, r *http.Request) {
+	var res groupResource
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		writeInvalidSyntax(w, r, err)
+		return
+	}
+
+	memberIDs, err := toMemberIDs(res.Members)
+	if err != nil {
+		writeError(w, r, err, "members must be user ids")
+		return
+	}
+
+	department, err := h.departments.CreateDepartment(r.Context(), res.DisplayName, memberIDs)
+	if err != nil {
+		writeGroupError(w, r, err, "failed to create group")
+		return
+	}
+
+	group := toGroupResource(department, baseURL(r))
+	w.Header().Set("Location", group.Meta.Location)
+	writeJSON(w, r, http.StatusCreated, group)
+}
+
+func (h *Handler) HandleReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errs.ErrDepartmentNotFound, "group not found")
+		return
+	}
+
+	var res groupResource
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		writeInvalidSyntax(w, r, err)
+		return
+	}
+
+	h.replaceGroup(w, r, id, &res)
+}
+
+// HandlePatchGroup applies the operations to the department, e.g. adding or removing members.
+func (h *Handler) HandlePatchGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errs.ErrDepartmentNotFound, "group not found")
+		return
+	}
+
+	var patch patchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeInvalidSyntax(w, r, err)
+		return
+	}
+
+	department, err := h.departments.GetDepartment(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "group not found")
+		return
+	}
+
+	current, err := toMap(toGroupResource(department, baseURL(r)))
+	if err != nil {
+		logger.FromContext(r.Context()).Error("failed to convert SCIM group", "error", err)
+		writeError(w, r, errs.ErrInternalServer, "failed to update group")
+		return
+	}
+
+	if err := applyPatch(current, patch.Operations); err != nil {
+		writeError(w, r, err, "invalid patch")
+		return
+	}
+
+	var res groupResource
+	if err := fromMap(current, &res); err != nil {
+		writeError(w, r, invalidValue("invalid value: %v", err), "")
+		return
+	}
+
+	h.replaceGroup(w, r, id, &res)
+}
+
+// HandleDeleteGroup deletes the department; its members are left without one.
+func (h *Handler) HandleDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errs.ErrDepartmentNotFound, "group not found")
+		return
+	}
+
+	if err := h.departments.DeleteDepartment(r.Context(), id); err != nil {
+		writeError(w, r, err, "failed to delete group")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request, id uuid.UUID, res *groupResource) {
+	memberIDs, err := toMemberIDs(res.Members)
+	if err != nil {
+		writeError(w, r, err, "members must be user ids")
+		return
+	}
+
+	department, err := h.departments.ReplaceDepartment(r.Context(), id, res.DisplayName, memberIDs)
+	if err != nil {
+		writeGroupError(w, r, err, "failed to update group")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, toGroupResource(department, baseURL(r)))
+}
+
+func toMemberIDs(members []reference) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, m := range members {
+		id, err := uuid.Parse(m.Value)
+		if err != nil {
+			return nil, errs.ErrInvalidRequest
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func excludesMembers(r *http.Request) bool {
+	for _, attr := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeGroupError reports unknown members as an invalid value rather than a missing group.
+func writeGroupError(w http.ResponseWriter, r *http.Request, err error, detail string) {
+	if errors.Is(err, errs.ErrUserNotFound) {
+		writeError(w, r, errs.ErrInvalidRequest, "members must be existing users")
+		return
+	}
+	writeError(w, r, err, detail)
+}
@@ -0,0 +1,580 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/logger"
+	depmodel "github.com/platonso/hrmate/internal/service/department/model"
+	userservice "github.com/platonso/hrmate/internal/service/user"
+	"github.com/platonso/hrmate/internal/service/user/model"
+)
+
+const (
+	defaultCount = 100
+	maxCount     = 1000
+	// maxFilterScan caps the users matched in memory against a filter that cannot
+	// be handed to the database; larger listings are refused with tooMany
+	maxFilterScan = 10000
+)
+
+type UserService interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	SearchUsers(ctx context.Context, filter *userservice.Filter, requesterRole domain.Role) (*userservice.Page, error)
+	CreateUser(ctx context.Context, input *model.CreateUserInput) (*domain.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, input *model.ProfileInput, expectedVersion *int) (*domain.User, error)
+	ChangeActiveStatus(ctx context.Context, userID uuid.UUID, newStatus bool, expectedVersion *int) (*domain.User, error)
+}
+
+type DepartmentService interface {
+	GetDepartments(ctx context.Context) ([]depmodel.DepartmentWithMembers, error)
+	GetDepartment(ctx context.Context, id uuid.UUID) (*depmodel.DepartmentWithMembers, error)
+	CreateDepartment(ctx context.Context, name string, memberIDs []uuid.UUID) (*depmodel.DepartmentWithMembers, error)
+	ReplaceDepartment(ctx context.Context, id uuid.UUID, name string, memberIDs []uuid.UUID) (*depmodel.DepartmentWithMembers, error)
+	DeleteDepartment(ctx context.Context, id uuid.UUID) error
+}
+
+// Handler serves SCIM 2.0 (RFC 7643, RFC 7644) to identity providers such as
+// Okta and Azure AD. Users are provisioned as employees and matched by userName,
+// their email; groups are departments. Service accounts are not exposed.
+type Handler struct {
+	users       UserService
+	departments DepartmentService
+}
+
+func NewHandler(users UserService, departments DepartmentService) *Handler {
+	return &Handler{
+		users:       users,
+		departments: departments,
+	}
+}
+
+// HandleGetUsers lists the users. The common filters of identity providers, equality
+// on userName, emails, active, externalId and the department, are answered by the
+// database along with the page; other expressions are matched in memory.
+func (h *Handler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
+	query, err := parseListQuery(r)
+	if err != nil {
+		writeError(w, r, err, "startIndex and count must be integers")
+		return
+	}
+
+	var filter filterExpr
+	if query.filter != "" {
+		if filter, err = parseFilter(query.filter); err != nil {
+			writeInvalidFilter(w, r, err)
+			return
+		}
+	}
+
+	departmentIDs, err := h.departmentIDs(r.Context())
+	if err != nil {
+		writeError(w, r, err, "failed to get users")
+		return
+	}
+
+	search := &userservice.Filter{Sort: userservice.SortByEmail, ExcludeServiceAccounts: true}
+	residual, matchable := pushDown(filter, search, departmentIDs)
+
+	var resp listResponse
+	switch {
+	case !matchable:
+		resp = query.response(nil, 0)
+	case residual == nil:
+		resp, err = h.usersPage(r, query, search, departmentIDs)
+	default:
+		resp, err = h.matchUsers(r, query, search, residual, departmentIDs)
+	}
+	if err != nil {
+		writeError(w, r, err, "failed to get users")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// usersPage reads the requested page of the users matching the search.
+func (h *Handler) usersPage(r *http.Request, query *listQuery, search *userservice.Filter, departmentIDs map[string]uuid.UUID) (listResponse, error) {
+	search.Offset = query.startIndex - 1
+	search.Limit = query.count
+	search.WithTotal = true
+	// A count of 0 only asks for the total, the service would apply its default instead
+	if query.count == 0 {
+		search.Limit = 1
+	}
+
+	page, err := h.users.SearchUsers(r.Context(), search, domain.RoleAdmin)
+	if err != nil {
+		return listResponse{}, err
+	}
+
+	base := baseURL(r)
+	resources := make([]any, 0, len(page.Users))
+	for i := range page.Users[:min(len(page.Users), query.count)] {
+		resources = append(resources, toUserResource(&page.Users[i], base, departmentIDs))
+	}
+	return query.response(resources, page.Total), nil
+}
+
+// matchUsers reads the users matching the search and keeps those matching the rest
+// of the filter. Without a narrow enough search the listing is refused.
+func (h *Handler) matchUsers(r *http.Request, query *listQuery, search *userservice.Filter, filter filterExpr, departmentIDs map[string]uuid.UUID) (listResponse, error) {
+	ctx := r.Context()
+	search.Limit = maxCount
+	search.WithTotal = true
+
+	base := baseURL(r)
+	var resources []any
+	for {
+		page, err := h.users.SearchUsers(ctx, search, domain.RoleAdmin)
+		if err != nil {
+			return listResponse{}, err
+		}
+		if page.Total > maxFilterScan {
+			return listResponse{}, &patchError{
+				scimType: "tooMany",
+				detail:   "the filter matches too many users, narrow it with equality on userName, emails, active or department",
+			}
+		}
+
+		for i := range page.Users {
+			res := toUserResource(&page.Users[i], base, departmentIDs)
+			m, err := toMap(res)
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to convert SCIM user", "error", err)
+				return listResponse{}, errs.ErrInternalServer
+			}
+			if filter.match(m) {
+				resources = append(resources, res)
+			}
+		}
+
+		if page.Next == nil {
+			return query.page(resources), nil
+		}
+		search.After = page.Next
+		search.WithTotal = false
+	}
+}
+
+func (h *Handler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.findUser(r)
+	if err != nil {
+		writeError(w, r, err, "user not found")
+		return
+	}
+
+	h.writeUser(w, r, http.StatusOK, user)
+}
+
+// HandleCreateUser provisions an active employee. Without a password the user
+// signs in through single sign-on.
+func (h *Handler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var res userResource
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		writeInvalidSyntax(w, r, err)
+		return
+	}
+
+	input, err := toCreateUserInput(&res)
+	if err != nil {
+		writeError(w, r, err, "userName must be an email, name.givenName and name.familyName are required")
+		return
+	}
+
+	user, err := h.users.CreateUser(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err, "failed to create user")
+		return
+	}
+
+	w.Header().Set("Location", baseURL(r)+"/Users/"+user.ID.String())
+	h.writeUser(w, r, http.StatusCreated, user)
+}
+
+// HandleReplaceUser replaces the attributes of the user; the attributes that are
+// left out, such as the department or the manager, are cleared.
+func (h *Handler) HandleReplaceUser(w http.ResponseWriter, r *http.Request) {
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		writeError(w, r, err, "the user has been changed")
+		return
+	}
+
+	user, err := h.findUser(r)
+	if err != nil {
+		writeError(w, r, err, "user not found")
+		return
+	}
+
+	var res userResource
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		writeInvalidSyntax(w, r, err)
+		return
+	}
+
+	if user, err = h.replaceUser(r.Context(), user, &res, expectedVersion); err != nil {
+		writeError(w, r, err, "failed to update user")
+		return
+	}
+
+	h.writeUser(w, r, http.StatusOK, user)
+}
+
+func (h *Handler) HandlePatchUser(w http.ResponseWriter, r *http.Request) {
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		writeError(w, r, err, "the user has been changed")
+		return
+	}
+
+	user, err := h.findUser(r)
+	if err != nil {
+		writeError(w, r, err, "user not found")
+		return
+	}
+
+	var patch patchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeInvalidSyntax(w, r, err)
+		return
+	}
+
+	departmentIDs, err := h.departmentIDs(r.Context())
+	if err != nil {
+		writeError(w, r, err, "failed to update user")
+		return
+	}
+
+	current, err := toMap(toUserResource(user, baseURL(r), departmentIDs))
+	if err != nil {
+		logger.FromContext(r.Context()).Error("failed to convert SCIM user", "error", err)
+		writeError(w, r, errs.ErrInternalServer, "failed to update user")
+		return
+	}
+
+	if err := applyPatch(current, patch.Operations); err != nil {
+		writeError(w, r, err, "invalid patch")
+		return
+	}
+
+	var res userResource
+	if err := fromMap(current, &res); err != nil {
+		writeError(w, r, invalidValue("invalid value: %v", err), "")
+		return
+	}
+
+	if user, err = h.replaceUser(r.Context(), user, &res, expectedVersion); err != nil {
+		writeError(w, r, err, "failed to update user")
+		return
+	}
+
+	h.writeUser(w, r, http.StatusOK, user)
+}
+
+// HandleDeleteUser deprovisions the user. The account is deactivated rather than
+// deleted, so that the forms of the user are kept.
+func (h *Handler) HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		writeError(w, r, err, "the user has been changed")
+		return
+	}
+
+	user, err := h.findUser(r)
+	if err != nil {
+		writeError(w, r, err, "user not found")
+		return
+	}
+
+	if _, err := h.users.ChangeActiveStatus(r.Context(), user.ID, false, expectedVersion); err != nil {
+		writeError(w, r, err, "failed to deactivate user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) replaceUser(ctx context.Context, user *domain.User, res *userResource, expectedVersion *int) (*domain.User, error) {
+	input, err := toProfileInput(res)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err = h.users.UpdateProfile(ctx, user.ID, input, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Active != nil && bool(*res.Active) != user.IsActive {
+		return h.users.ChangeActiveStatus(ctx, user.ID, bool(*res.Active), &user.Version)
+	}
+	return user, nil
+}
+
+func (h *Handler) findUser(r *http.Request) (*domain.User, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, errs.ErrUserNotFound
+	}
+
+	user, err := h.users.GetUserByID(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsServiceAccount {
+		return nil, errs.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// departmentIDs maps the names of the departments to their ids, the groups of a user.
+func (h *Handler) departmentIDs(ctx context.Context) (map[string]uuid.UUID, error) {
+	departments, err := h.departments.GetDepartments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]uuid.UUID, len(departments))
+	for _, d := range departments {
+		ids[d.Department.Name] = d.Department.ID
+	}
+	return ids, nil
+}
+
+func (h *Handler) writeUser(w http.ResponseWriter, r *http.Request, status int, user *domain.User) {
+	departmentIDs, err := h.departmentIDs(r.Context())
+	if err != nil {
+		writeError(w, r, err, "failed to get user")
+		return
+	}
+
+	res := toUserResource(user, baseURL(r), departmentIDs)
+	w.Header().Set("ETag", res.Meta.Version)
+	writeJSON(w, r, status, res)
+}
+
+func toCreateUserInput(res *userResource) (*model.CreateUserInput, error) {
+	profile, err := toProfileInput(res)
+	if err != nil {
+		return nil, err
+	}
+	if profile.FirstName == nil || profile.LastName == nil {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	input := &model.CreateUserInput{
+		Role:       domain.RoleEmployee,
+		FirstName:  *profile.FirstName,
+		LastName:   *profile.LastName,
+		Position:   *profile.Position,
+		Email:      *profile.Email,
+		Password:   res.Password,
+		Department: *profile.Department,
+		IsActive:   true,
+	}
+	if *profile.ManagerID != uuid.Nil {
+		input.ManagerID = profile.ManagerID
+	}
+	if res.Active != nil {
+		input.IsActive = bool(*res.Active)
+	}
+	return input, nil
+}
+
+// toProfileInput sets every attribute of the profile, as the resource replaces the user.
+func toProfileInput(res *userResource) (*model.ProfileInput, error) {
+	address, err := mail.ParseAddress(res.UserName)
+	if err != nil || address.Address != strings.TrimSpace(res.UserName) {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	var firstName, lastName string
+	if res.Name != nil {
+		firstName, lastName = strings.TrimSpace(res.Name.GivenName), strings.TrimSpace(res.Name.FamilyName)
+	}
+	position := strings.TrimSpace(res.Title)
+	email := strings.ToLower(address.Address)
+
+	var department string
+	managerID := uuid.Nil
+	if res.Enterprise != nil {
+		department = strings.TrimSpace(res.Enterprise.Department)
+		if res.Enterprise.Manager != nil && res.Enterprise.Manager.Value != "" {
+			if managerID, err = uuid.Parse(res.Enterprise.Manager.Value); err != nil {
+				return nil, errs.ErrInvalidRequest
+			}
+		}
+	}
+
+	input := &model.ProfileInput{
+		Position:   &position,
+		Email:      &email,
+		Department: &department,
+		ManagerID:  &managerID,
+	}
+	// The name cannot be cleared, a resource without it keeps the current one
+	if firstName != "" {
+		input.FirstName = &firstName
+	}
+	if lastName != "" {
+		input.LastName = &lastName
+	}
+	return input, nil
+}
+
+// pushDown moves the equality terms of a conjunction that the database can answer
+// into search and returns what remains to be matched in memory. matchable is false
+// when no user can match, e.g. for externalId, which is not stored.
+func pushDown(filter filterExpr, search *userservice.Filter, departmentIDs map[string]uuid.UUID) (residual filterExpr, matchable bool) {
+	if filter == nil {
+		return nil, true
+	}
+
+	if and, ok := filter.(andExpr); ok {
+		left, ok := pushDown(and.left, search, departmentIDs)
+		if !ok {
+			return nil, false
+		}
+		right, ok := pushDown(and.right, search, departmentIDs)
+		if !ok {
+			return nil, false
+		}
+		switch {
+		case left == nil:
+			return right, true
+		case right == nil:
+			return left, true
+		default:
+			return andExpr{left: left, right: right}, true
+		}
+	}
+
+	cmp, ok := filter.(compareExpr)
+	if !ok || cmp.op != "eq" {
+		return filter, true
+	}
+
+	path := splitPath(cmp.path)
+	attr := strings.Join(path, ".")
+
+	switch value := cmp.value.(type) {
+	case string:
+		switch {
+		case strings.EqualFold(attr, "userName"), strings.EqualFold(attr, "emails"), strings.EqualFold(attr, "emails.value"):
+			if search.Email != nil && !strings.EqualFold(*search.Email, value) {
+				return nil, false
+			}
+			search.Email = &value
+			return nil, true
+
+		case strings.EqualFold(attr, "externalId"):
+			return nil, false
+
+		case len(path) == 2 && path[0] == schemaEnterpriseUser && strings.EqualFold(path[1], "department"):
+			// Departments are compared case-insensitively, the database by the stored name
+			var names []string
+			for name := range departmentIDs {
+				if strings.EqualFold(name, value) {
+					names = append(names, name)
+				}
+			}
+			switch {
+			case len(names) == 0:
+				return nil, false
+			case len(names) > 1:
+				return filter, true
+			case search.Department != nil && *search.Department != names[0]:
+				return nil, false
+			}
+			search.Department = &names[0]
+			return nil, true
+		}
+
+	case bool:
+		if strings.EqualFold(attr, "active") {
+			if search.IsActive != nil && *search.IsActive != value {
+				return nil, false
+			}
+			search.IsActive = &value
+			return nil, true
+		}
+	}
+
+	return filter, true
+}
+
+type listQuery struct {
+	filter     string
+	startIndex int
+	count      int
+}
+
+// parseListQuery reads the filter and the 1-based pagination of RFC 7644, section 3.4.2.4.
+func parseListQuery(r *http.Request) (*listQuery, error) {
+	query := r.URL.Query()
+	q := &listQuery{
+		filter:     strings.TrimSpace(query.Get("filter")),
+		startIndex: 1,
+		count:      defaultCount,
+	}
+
+	if v := query.Get("startIndex"); v != "" {
+		startIndex, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errs.ErrInvalidRequest
+		}
+		q.startIndex = max(startIndex, 1)
+	}
+
+	if v := query.Get("count"); v != "" {
+		count, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errs.ErrInvalidRequest
+		}
+		q.count = min(max(count, 0), maxCount)
+	}
+
+	return q, nil
+}
+
+// page cuts the requested page out of all the matching resources.
+func (q *listQuery) page(resources []any) listResponse {
+	start := min(q.startIndex-1, len(resources))
+	end := min(start+q.count, len(resources))
+
+	return q.response(resources[start:end], len(resources))
+}
+
+// response lists the resources of the requested page out of total matching ones.
+func (q *listQuery) response(resources []any, total int) listResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+
+	return listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   q.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// baseURL is the address of the SCIM API as the client reached it.
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/scim/v2"
+}
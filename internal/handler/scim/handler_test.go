@@ -0,0 +1,136 @@
+package scim
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	userservice "github.com/platonso/hrmate/internal/service/user"
+)
+
+func TestPushDown(t *testing.T) {
+	departmentIDs := map[string]uuid.UUID{"Sales": uuid.New(), "Support": uuid.New()}
+
+	ptr := func(s string) *string { return &s }
+	active := true
+
+	tests := []struct {
+		name          string
+		filter        string
+		departmentIDs map[string]uuid.UUID
+		want          userservice.Filter
+		residual      string
+		unmatchable   bool
+	}{
+		{
+			name:   "userName",
+			filter: `userName eq "Ann@example.com"`,
+			want:   userservice.Filter{Email: ptr("Ann@example.com")},
+		},
+		{
+			name:   "emails.value",
+			filter: `emails.value eq "ann@example.com"`,
+			want:   userservice.Filter{Email: ptr("ann@example.com")},
+		},
+		{
+			name:   "emails and the schema URN",
+			filter: `urn:ietf:params:scim:schemas:core:2.0:User:Emails eq "ann@example.com"`,
+			want:   userservice.Filter{Email: ptr("ann@example.com")},
+		},
+		{
+			name:   "the same email twice",
+			filter: `userName eq "ann@example.com" and emails.value eq "ANN@example.com"`,
+			want:   userservice.Filter{Email: ptr("ANN@example.com")},
+		},
+		{
+			name:        "two different emails",
+			filter:      `userName eq "ann@example.com" and emails.value eq "bob@example.com"`,
+			unmatchable: true,
+		},
+		{
+			name:   "enterprise department by its stored name",
+			filter: schemaEnterpriseUser + `:department eq "sales"`,
+			want:   userservice.Filter{Department: ptr("Sales")},
+		},
+		{
+			name:        "unknown department",
+			filter:      schemaEnterpriseUser + `:department eq "Marketing"`,
+			unmatchable: true,
+		},
+		{
+			name:        "two different departments",
+			filter:      schemaEnterpriseUser + `:department eq "Sales" and ` + schemaEnterpriseUser + `:department eq "Support"`,
+			unmatchable: true,
+		},
+		{
+			name:          "department names differing only in case",
+			filter:        schemaEnterpriseUser + `:department eq "sales"`,
+			departmentIDs: map[string]uuid.UUID{"Sales": uuid.New(), "SALES": uuid.New()},
+			residual:      schemaEnterpriseUser + `:department eq "sales"`,
+		},
+		{
+			name:     "the rest of a conjunction is matched in memory",
+			filter:   `active eq true and emails.value eq "ann@example.com" and title co "eng"`,
+			want:     userservice.Filter{IsActive: &active, Email: ptr("ann@example.com")},
+			residual: `title co "eng"`,
+		},
+		{
+			name:     "value filters stay in memory",
+			filter:   `userName eq "ann@example.com" and emails[type eq "work"]`,
+			want:     userservice.Filter{Email: ptr("ann@example.com")},
+			residual: `emails[type eq "work"]`,
+		},
+		{
+			name:     "disjunctions stay in memory",
+			filter:   `userName eq "ann@example.com" or active eq true`,
+			residual: `userName eq "ann@example.com" or active eq true`,
+		},
+		{
+			name:     "only equality is pushed down",
+			filter:   `userName ne "ann@example.com"`,
+			residual: `userName ne "ann@example.com"`,
+		},
+		{
+			name:        "externalId is not stored",
+			filter:      `externalId eq "42" and active eq true`,
+			unmatchable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseFilter(%q): %v", tt.filter, err)
+			}
+			ids := tt.departmentIDs
+			if ids == nil {
+				ids = departmentIDs
+			}
+
+			var search userservice.Filter
+			residual, matchable := pushDown(filter, &search, ids)
+
+			if matchable == tt.unmatchable {
+				t.Fatalf("matchable = %v, want %v", matchable, !tt.unmatchable)
+			}
+			if tt.unmatchable {
+				return
+			}
+
+			if !reflect.DeepEqual(search, tt.want) {
+				t.Errorf("search = %+v, want %+v", search, tt.want)
+			}
+
+			var want filterExpr
+			if tt.residual != "" {
+				if want, err = parseFilter(tt.residual); err != nil {
+					t.Fatalf("parseFilter(%q): %v", tt.residual, err)
+				}
+			}
+			if !reflect.DeepEqual(residual, want) {
+				t.Errorf("residual = %#v, want %#v", residual, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// patchError is an operation that cannot be applied; scimType is one of the
+// error types of RFC 7644, section 3.12.
+type patchError struct {
+	scimType string
+	detail   string
+}
+
+func (e *patchError) Error() string { return e.detail }
+
+func invalidPath(format string, args ...any) error {
+	return &patchError{scimType: "invalidPath", detail: fmt.Sprintf(format, args...)}
+}
+
+func invalidValue(format string, args ...any) error {
+	return &patchError{scimType: "invalidValue", detail: fmt.Sprintf(format, args...)}
+}
+
+func noTarget(format string, args ...any) error {
+	return &patchError{scimType: "noTarget", detail: fmt.Sprintf(format, args...)}
+}
+
+// applyPatch applies the operations (RFC 7644, section 3.5.2) to the JSON form of a resource.
+func applyPatch(resource map[string]any, operations []patchOperation) error {
+	if len(operations) == 0 {
+		return invalidValue("no operations")
+	}
+
+	for _, op := range operations {
+		var value any
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return invalidValue("invalid value of operation %q", op.Op)
+			}
+		}
+
+		name := strings.ToLower(op.Op)
+		switch name {
+		case "add", "replace", "remove":
+		default:
+			return invalidValue("unknown operation %q", op.Op)
+		}
+
+		if err := applyOperation(resource, name, strings.TrimSpace(op.Path), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOperation(resource map[string]any, op, path string, value any) error {
+	if path != "" {
+		return applyPath(resource, op, path, value)
+	}
+
+	// Without a path the value holds the attributes to add or replace
+	if op == "remove" {
+		return noTarget("remove requires a path")
+	}
+	attributes, ok := value.(map[string]any)
+	if !ok {
+		return invalidValue("value must be an object when there is no path")
+	}
+
+	for key, v := range attributes {
+		if strings.EqualFold(key, "schemas") {
+			continue
+		}
+		if err := applyPath(resource, op, key, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPath applies the operation at a path such as "title", "name.givenName",
+// "members[value eq \"id\"]" or "emails[type eq \"work\"].value".
+func applyPath(resource map[string]any, op, path string, value any) error {
+	attr, filterText, sub, err := splitFilteredPath(path)
+	if err != nil {
+		return err
+	}
+
+	keys := splitPath(attr)
+	parent := resource
+	for _, key := range keys[:len(keys)-1] {
+		k := lookupKey(parent, key)
+		child, ok := parent[k].(map[string]any)
+		if !ok {
+			if op == "remove" {
+				return nil
+			}
+			child = map[string]any{}
+			parent[k] = child
+		}
+		parent = child
+	}
+	key := lookupKey(parent, keys[len(keys)-1])
+
+	if filterText == "" {
+		return applyAttribute(parent, key, op, value)
+	}
+
+	filter, err := parseFilter(filterText)
+	if err != nil {
+		return invalidPath("invalid filter in path %q: %v", path, err)
+	}
+	return applyFiltered(parent, key, filter, sub, op, value, path)
+}
+
+// splitFilteredPath splits `emails[type eq "work"].value` into the attribute,
+// the filter and the sub-attribute.
+func splitFilteredPath(path string) (attr, filter, sub string, err error) {
+	open := strings.IndexByte(path, '[')
+	if open < 0 {
+		return path, "", "", nil
+	}
+
+	end := strings.LastIndexByte(path, ']')
+	if end < open {
+		return "", "", "", invalidPath("invalid path %q", path)
+	}
+
+	attr, filter, rest := path[:open], path[open+1:end], path[end+1:]
+	if rest != "" {
+		if !strings.HasPrefix(rest, ".") || len(rest) == 1 {
+			return "", "", "", invalidPath("invalid path %q", path)
+		}
+		sub = rest[1:]
+	}
+	return attr, filter, sub, nil
+}
+
+func applyAttribute(parent map[string]any, key, op string, value any) error {
+	current, exists := parent[key]
+
+	switch op {
+	case "remove":
+		// A value selects the elements to remove, as Azure AD does for members
+		if values, ok := value.([]any); ok && exists {
+			if list, ok := current.([]any); ok {
+				parent[key] = slices.DeleteFunc(list, func(item any) bool {
+					return slices.ContainsFunc(values, func(v any) bool { return sameValue(item, v) })
+				})
+				return nil
+			}
+		}
+		delete(parent, key)
+
+	case "add":
+		switch cur := current.(type) {
+		case []any:
+			values, ok := value.([]any)
+			if !ok {
+				values = []any{value}
+			}
+			for _, v := range values {
+				if !slices.ContainsFunc(cur, func(item any) bool { return sameValue(item, v) }) {
+					cur = append(cur, v)
+				}
+			}
+			parent[key] = cur
+		case map[string]any:
+			values, ok := value.(map[string]any)
+			if !ok {
+				return invalidValue("value of %q must be an object", key)
+			}
+			for k, v := range values {
+				cur[lookupKey(cur, k)] = v
+			}
+		default:
+			parent[key] = value
+		}
+
+	case "replace":
+		cur, isMap := current.(map[string]any)
+		values, ok := value.(map[string]any)
+		if isMap && ok {
+			// Sub-attributes that are not mentioned are kept
+			for k, v := range values {
+				cur[lookupKey(cur, k)] = v
+			}
+			return nil
+		}
+		parent[key] = value
+	}
+	return nil
+}
+
+func applyFiltered(parent map[string]any, key string, filter filterExpr, sub, op string, value any, path string) error {
+	list, _ := parent[key].([]any)
+
+	matched := false
+	kept := list[:0:0]
+	for _, item := range list {
+		element, ok := item.(map[string]any)
+		if !ok || !filter.match(element) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+
+		switch {
+		case op == "remove" && sub == "":
+			continue
+		case op == "remove":
+			delete(element, lookupKey(element, sub))
+		case sub != "":
+			element[lookupKey(element, sub)] = value
+		default:
+			replacement, ok := value.(map[string]any)
+			if !ok {
+				return invalidValue("value of %q must be an object", path)
+			}
+			for k, v := range replacement {
+				element[lookupKey(element, k)] = v
+			}
+		}
+		kept = append(kept, element)
+	}
+
+	if !matched {
+		if op == "remove" {
+			return nil
+		}
+
+		// add and replace create the element the filter describes,
+		// e.g. emails[type eq "work"].value adds a work email
+		cmp, ok := filter.(compareExpr)
+		if !ok || cmp.op != "eq" || sub == "" {
+			return noTarget("no value matches %q", path)
+		}
+		kept = append(kept, map[string]any{cmp.path: cmp.value, sub: value})
+	}
+
+	parent[key] = kept
+	return nil
+}
+
+// sameValue compares elements of a multi-valued attribute by their value.
+func sameValue(a, b any) bool {
+	return reflect.DeepEqual(elementValue(a), elementValue(b))
+}
+
+func elementValue(item any) any {
+	if m, ok := item.(map[string]any); ok {
+		return m[lookupKey(m, "value")]
+	}
+	return item
+}
@@ -0,0 +1,175 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// patchUser is the JSON form of a user with two emails, fresh for every test.
+func patchUser() map[string]any {
+	return map[string]any{
+		"userName": "ann@example.com",
+		"title":    "Engineer",
+		"name":     map[string]any{"givenName": "Ann", "familyName": "Lee"},
+		"emails": []any{
+			map[string]any{"value": "ann@example.com", "type": "work", "primary": true},
+			map[string]any{"value": "ann@home.example", "type": "home"},
+		},
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		op    string
+		path  string
+		value string
+		want  func(res map[string]any)
+	}{
+		{
+			name:  "replace the sub-attribute of the matching element",
+			op:    "replace",
+			path:  `emails[type eq "work"].value`,
+			value: `"ann.lee@example.com"`,
+			want: func(res map[string]any) {
+				res["emails"].([]any)[0].(map[string]any)["value"] = "ann.lee@example.com"
+			},
+		},
+		{
+			name:  "filter and sub-attribute are case-insensitive",
+			op:    "Replace",
+			path:  `Emails[TYPE eq "HOME"].Value`,
+			value: `"ann@lee.example"`,
+			want: func(res map[string]any) {
+				res["emails"].([]any)[1].(map[string]any)["value"] = "ann@lee.example"
+			},
+		},
+		{
+			name:  "add creates the element the filter describes",
+			op:    "add",
+			path:  `emails[type eq "other"].value`,
+			value: `"ann@other.example"`,
+			want: func(res map[string]any) {
+				res["emails"] = append(res["emails"].([]any), map[string]any{"type": "other", "value": "ann@other.example"})
+			},
+		},
+		{
+			name:  "replace merges an object into the matching element",
+			op:    "replace",
+			path:  `emails[type eq "work"]`,
+			value: `{"primary": false}`,
+			want: func(res map[string]any) {
+				res["emails"].([]any)[0].(map[string]any)["primary"] = false
+			},
+		},
+		{
+			name: "remove the matching element",
+			op:   "remove",
+			path: `emails[type eq "home"]`,
+			want: func(res map[string]any) {
+				res["emails"] = res["emails"].([]any)[:1]
+			},
+		},
+		{
+			name: "remove the sub-attribute of the matching element",
+			op:   "remove",
+			path: `emails[value co "@home.example"].type`,
+			want: func(res map[string]any) {
+				delete(res["emails"].([]any)[1].(map[string]any), "type")
+			},
+		},
+		{
+			name: "remove without a matching element",
+			op:   "remove",
+			path: `emails[type eq "other"]`,
+			want: func(map[string]any) {},
+		},
+		{
+			name:  "replace a nested attribute",
+			op:    "replace",
+			path:  "name.givenName",
+			value: `"Anna"`,
+			want: func(res map[string]any) {
+				res["name"].(map[string]any)["givenName"] = "Anna"
+			},
+		},
+		{
+			name:  "add without a path",
+			op:    "add",
+			value: `{"title": "Manager", "name": {"familyName": "Kim"}}`,
+			want: func(res map[string]any) {
+				res["title"] = "Manager"
+				res["name"].(map[string]any)["familyName"] = "Kim"
+			},
+		},
+		{
+			name: "remove an attribute",
+			op:   "remove",
+			path: "title",
+			want: func(res map[string]any) {
+				delete(res, "title")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := patchUser()
+			op := patchOperation{Op: tt.op, Path: tt.path}
+			if tt.value != "" {
+				op.Value = json.RawMessage(tt.value)
+			}
+			if err := applyPatch(got, []patchOperation{op}); err != nil {
+				t.Fatalf("applyPatch: %v", err)
+			}
+
+			want := patchUser()
+			tt.want(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("applyPatch = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		path     string
+		value    string
+		scimType string
+	}{
+		{name: "unclosed filter", op: "replace", path: `emails[type eq "work"`, value: `"x"`, scimType: "invalidPath"},
+		{name: "closing bracket first", op: "replace", path: `emails]type eq "work"[`, value: `"x"`, scimType: "invalidPath"},
+		{name: "sub-attribute without a dot", op: "replace", path: `emails[type eq "work"]value`, value: `"x"`, scimType: "invalidPath"},
+		{name: "empty sub-attribute", op: "replace", path: `emails[type eq "work"].`, value: `"x"`, scimType: "invalidPath"},
+		{name: "invalid filter", op: "replace", path: `emails[type eq].value`, value: `"x"`, scimType: "invalidPath"},
+		{name: "unknown operator in filter", op: "remove", path: `emails[type is "work"]`, scimType: "invalidPath"},
+		{name: "no element to replace", op: "replace", path: `emails[type co "other"].value`, value: `"x"`, scimType: "noTarget"},
+		{name: "remove without a path", op: "remove", scimType: "noTarget"},
+		{name: "element replaced by a string", op: "replace", path: `emails[type eq "work"]`, value: `"x"`, scimType: "invalidValue"},
+		{name: "unknown operation", op: "move", path: "title", value: `"x"`, scimType: "invalidValue"},
+		{name: "invalid value", op: "replace", path: "title", value: `{`, scimType: "invalidValue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := patchOperation{Op: tt.op, Path: tt.path}
+			if tt.value != "" {
+				op.Value = json.RawMessage(tt.value)
+			}
+
+			err := applyPatch(patchUser(), []patchOperation{op})
+			var patchErr *patchError
+			if !errors.As(err, &patchErr) {
+				t.Fatalf("applyPatch error = %v, want a patch error", err)
+			}
+			if patchErr.scimType != tt.scimType {
+				t.Errorf("scimType = %q, want %q (%s)", patchErr.scimType, tt.scimType, patchErr.detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/service/department/model"
+)
+
+const (
+	schemaUser           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaEnterpriseUser = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	schemaGroup          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError          = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// userResource is a user as an identity provider sees it. userName is the email
+// of the user, title their position and the groups are departments.
+type userResource struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	UserName    string          `json:"userName"`
+	Name        *userName       `json:"name,omitempty"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Title       string          `json:"title,omitempty"`
+	Emails      []email         `json:"emails,omitempty"`
+	Active      *flexBool       `json:"active,omitempty"`
+	Password    string          `json:"password,omitempty"`
+	Groups      []reference     `json:"groups,omitempty"`
+	Enterprise  *enterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *meta           `json:"meta,omitempty"`
+}
+
+type userName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	Formatted  string `json:"formatted,omitempty"`
+}
+
+type email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type enterpriseUser struct {
+	Department string     `json:"department,omitempty"`
+	Manager    *reference `json:"manager,omitempty"`
+}
+
+type groupResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []reference `json:"members,omitempty"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+// reference points to another resource: a member of a group, a group of a user or a manager.
+type reference struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// UnmarshalJSON also accepts a bare id, as some providers send the manager that way.
+func (ref *reference) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		ref.Value = value
+		return nil
+	}
+
+	type plain reference
+	return json.Unmarshal(data, (*plain)(ref))
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// flexBool also accepts "True" and "False", which Azure AD sends in PATCH requests.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, ok := toBool(value)
+	if !ok {
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	*b = flexBool(parsed)
+	return nil
+}
+
+func toBool(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(v))
+		return parsed, err == nil
+	}
+	return false, false
+}
+
+// toUserResource converts the user; departmentIDs maps department names to ids.
+func toUserResource(user *domain.User, baseURL string, departmentIDs map[string]uuid.UUID) userResource {
+	active := flexBool(user.IsActive)
+	res := userResource{
+		Schemas:  []string{schemaUser, schemaEnterpriseUser},
+		ID:       user.ID.String(),
+		UserName: user.Email,
+		Name: &userName{
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+			Formatted:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+		},
+		DisplayName: strings.TrimSpace(user.FirstName + " " + user.LastName),
+		Title:       user.Position,
+		Emails:      []email{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &meta{
+			ResourceType: "User",
+			Location:     baseURL + "/Users/" + user.ID.String(),
+			Version:      version(user.Version),
+		},
+	}
+
+	if user.Department != "" || user.ManagerID != nil {
+		res.Enterprise = &enterpriseUser{Department: user.Department}
+		if user.ManagerID != nil {
+			res.Enterprise.Manager = &reference{Value: user.ManagerID.String()}
+		}
+	}
+
+	if id, ok := departmentIDs[user.Department]; ok && user.Department != "" {
+		res.Groups = []reference{{Value: id.String(), Display: user.Department}}
+	}
+
+	return res
+}
+
+func toGroupResource(department *model.DepartmentWithMembers, baseURL string) groupResource {
+	res := groupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          department.Department.ID.String(),
+		DisplayName: department.Department.Name,
+		Members:     make([]reference, len(department.Members)),
+		Meta: &meta{
+			ResourceType: "Group",
+			Location:     baseURL + "/Groups/" + department.Department.ID.String(),
+		},
+	}
+
+	for i, member := range department.Members {
+		res.Members[i] = reference{
+			Value:   member.ID.String(),
+			Display: strings.TrimSpace(member.FirstName + " " + member.LastName),
+		}
+	}
+	return res
+}
+
+// version is the ETag of the resource, as in the ETag header of the REST API,
+// so that it can be sent back in If-Match.
+func version(v int) string {
+	return strconv.Quote(strconv.Itoa(v))
+}
+
+// toMap converts a resource to the JSON form that filters and patches work on.
+func toMap(resource any) (map[string]any, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func fromMap(m map[string]any, resource any) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, resource)
+}
+
+// resolve returns the values at the attribute path, e.g. "name.givenName",
+// "emails.value" or a path prefixed with a schema URN. The values of
+// multi-valued attributes are returned one by one.
+func resolve(resource map[string]any, path string) []any {
+	var current any = resource
+	for _, part := range splitPath(path) {
+		var next []any
+		for _, v := range flatten(current) {
+			m, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			if value, ok := m[lookupKey(m, part)]; ok {
+				next = append(next, value)
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		current = next
+	}
+	return flatten(current)
+}
+
+func flatten(value any) []any {
+	values, ok := value.([]any)
+	if !ok {
+		return []any{value}
+	}
+
+	var result []any
+	for _, v := range values {
+		result = append(result, flatten(v)...)
+	}
+	return result
+}
+
+// splitPath splits the attribute path into the keys of the JSON form. The URN
+// of the core schemas is dropped, an extension URN is a key of its own.
+func splitPath(path string) []string {
+	lower := strings.ToLower(path)
+	for _, urn := range []string{schemaEnterpriseUser, schemaUser, schemaGroup} {
+		prefix := strings.ToLower(urn) + ":"
+		if !strings.HasPrefix(lower, prefix) {
+			continue
+		}
+
+		rest := strings.Split(path[len(prefix):], ".")
+		if urn == schemaEnterpriseUser {
+			return append([]string{urn}, rest...)
+		}
+		return rest
+	}
+
+	if strings.EqualFold(path, schemaEnterpriseUser) {
+		return []string{schemaEnterpriseUser}
+	}
+	return strings.Split(path, ".")
+}
+
+// lookupKey returns the key of m that matches key case-insensitively, or key itself.
+func lookupKey(m map[string]any, key string) string {
+	if _, ok := m[key]; ok {
+		return key
+	}
+	for k := range m {
+		if strings.EqualFold(k, key) {
+			return k
+		}
+	}
+	return key
+}
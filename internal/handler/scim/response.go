@@ -0,0 +1,109 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/logger"
+)
+
+const contentType = "application/scim+json"
+
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, data any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		logger.FromContext(r.Context()).Error("failed to encode SCIM response", "error", err)
+	}
+}
+
+// writeError writes the error of RFC 7644, section 3.12. Identity providers log
+// the detail rather than show it, so it is not translated.
+func writeError(w http.ResponseWriter, r *http.Request, err error, detail string) {
+	var status int
+	var scimType string
+
+	var patchErr *patchError
+	switch {
+	case errors.As(err, &patchErr):
+		status, scimType, detail = http.StatusBadRequest, patchErr.scimType, patchErr.detail
+
+	case errors.Is(err, errs.ErrUserAlreadyExists),
+		errors.Is(err, errs.ErrDepartmentAlreadyExists):
+		status, scimType = http.StatusConflict, "uniqueness"
+
+	case errors.Is(err, errs.ErrLastAdmin),
+		errors.Is(err, errs.ErrNoAvailableExecutors):
+		status = http.StatusConflict
+
+	case errors.Is(err, errs.ErrUserNotFound),
+		errors.Is(err, errs.ErrDepartmentNotFound):
+		status = http.StatusNotFound
+
+	case errors.Is(err, errs.ErrInvalidRequest),
+		errors.Is(err, errs.ErrPasswordTooShort),
+		errors.Is(err, errs.ErrPasswordBreached),
+		errors.Is(err, errs.ErrPasswordMatchesEmail):
+		status, scimType = http.StatusBadRequest, "invalidValue"
+
+	case errors.Is(err, errs.ErrForbidden):
+		status = http.StatusForbidden
+
+	case errors.Is(err, errs.ErrPreconditionFailed):
+		status = http.StatusPreconditionFailed
+
+	case errors.Is(err, errs.ErrBodyTooLarge):
+		status = http.StatusRequestEntityTooLarge
+
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	writeJSON(w, r, status, errorResponse{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func writeInvalidFilter(w http.ResponseWriter, r *http.Request, err error) {
+	writeJSON(w, r, http.StatusBadRequest, errorResponse{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(http.StatusBadRequest),
+		ScimType: "invalidFilter",
+		Detail:   "invalid filter: " + err.Error(),
+	})
+}
+
+func writeInvalidSyntax(w http.ResponseWriter, r *http.Request, err error) {
+	if request.IsBodyTooLarge(err) {
+		writeError(w, r, errs.ErrBodyTooLarge, "request body is too large")
+		return
+	}
+	writeJSON(w, r, http.StatusBadRequest, errorResponse{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(http.StatusBadRequest),
+		ScimType: "invalidSyntax",
+		Detail:   "invalid request body",
+	})
+}
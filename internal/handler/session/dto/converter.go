@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+)
+
+func ToSessionResponse(session *domain.Session, currentID uuid.UUID) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		Device:     session.Device,
+		IPAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.ID == currentID,
+
+		ImpersonatorID: session.ImpersonatorID,
+	}
+}
+
+func ToSessionResponses(sessions []domain.Session, currentID uuid.UUID) []SessionResponse {
+	if len(sessions) == 0 {
+		return []SessionResponse{}
+	}
+
+	responses := make([]SessionResponse, len(sessions))
+	for i := range sessions {
+		responses[i] = ToSessionResponse(&sessions[i], currentID)
+	}
+	return responses
+}
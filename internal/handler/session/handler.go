@@ -0,0 +1,83 @@
+package session
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/middleware"
+	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/handler/session/dto"
+)
+
+type Service interface {
+	GetSessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeAll(ctx context.Context, userID uuid.UUID) error
+}
+
+type Handler struct {
+	svc Service
+}
+
+func NewHandler(svc Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) HandleGetSessions(w http.ResponseWriter, r *http.Request) {
+	requesterID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	sessions, err := h.svc.GetSessions(r.Context(), requesterID)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to get sessions")
+		return
+	}
+
+	currentID, _ := middleware.GetSessionID(r.Context())
+	response.WriteJSON(w, http.StatusOK, dto.ToSessionResponses(sessions, currentID))
+}
+
+func (h *Handler) HandleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid session id format")
+		return
+	}
+
+	requesterID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	if err := h.svc.Revoke(r.Context(), requesterID, sessionID); err != nil {
+		response.WriteError(w, r, err, "failed to revoke session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) HandleRevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	if err := h.svc.RevokeAll(r.Context(), userID); err != nil {
+		response.WriteError(w, r, err, "failed to revoke user's sessions")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,5 @@
+package dto
+
+type AuthResponse struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,60 @@
+package sso
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/handler/sso/dto"
+)
+
+type Service interface {
+	BeginLogin(ctx context.Context) (string, error)
+	CompleteLogin(ctx context.Context, code, state string, client domain.ClientInfo) (string, error)
+}
+
+type Handler struct {
+	svc Service
+}
+
+func NewHandler(svc Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	authURL, err := h.svc.BeginLogin(r.Context())
+	if err != nil {
+		response.WriteError(w, r, err, "failed to start single sign-on")
+		return
+	}
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+func (h *Handler) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("error") != "" {
+		response.WriteError(w, r, errs.ErrInvalidCredentials, "identity provider rejected the login: "+query.Get("error"))
+		return
+	}
+
+	code, state := query.Get("code"), query.Get("state")
+	if code == "" || state == "" {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "code and state are required")
+		return
+	}
+
+	token, err := h.svc.CompleteLogin(r.Context(), code, state, request.ClientInfo(r))
+	if err != nil {
+		response.WriteError(w, r, err, "failed to complete single sign-on")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, dto.AuthResponse{Token: token})
+}
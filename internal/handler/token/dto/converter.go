@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/service/token/model"
+)
+
+func ToTokenCreateInput(req *TokenCreateRequest) *model.TokenCreateInput {
+	scopes := make([]domain.Scope, len(req.Scopes))
+	for i := range req.Scopes {
+		scopes[i] = domain.Scope(req.Scopes[i])
+	}
+
+	return &model.TokenCreateInput{
+		Name:      req.Name,
+		Scopes:    scopes,
+		ExpiresIn: time.Duration(req.ExpiresInDays) * 24 * time.Hour,
+	}
+}
+
+func ToServiceAccountCreateInput(req *ServiceAccountCreateRequest) *model.ServiceAccountCreateInput {
+	return &model.ServiceAccountCreateInput{
+		Name: req.Name,
+		Role: domain.Role(req.Role),
+	}
+}
+
+func ToTokenResponse(token *domain.APIToken) TokenResponse {
+	scopes := make([]string, len(token.Scopes))
+	for i := range token.Scopes {
+		scopes[i] = string(token.Scopes[i])
+	}
+
+	return TokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+	}
+}
+
+func ToTokenResponses(tokens []domain.APIToken) []TokenResponse {
+	if len(tokens) == 0 {
+		return []TokenResponse{}
+	}
+
+	responses := make([]TokenResponse, len(tokens))
+	for i := range tokens {
+		responses[i] = ToTokenResponse(&tokens[i])
+	}
+	return responses
+}
+
+func ToCreatedTokenResponse(created *model.CreatedToken) CreatedTokenResponse {
+	return CreatedTokenResponse{
+		TokenResponse: ToTokenResponse(&created.Token),
+		Token:         created.RawToken,
+	}
+}
+
+func ToServiceAccountResponse(account *domain.User) ServiceAccountResponse {
+	return ServiceAccountResponse{
+		ID:       account.ID,
+		Role:     string(account.Role),
+		Name:     account.FirstName,
+		Email:    account.Email,
+		IsActive: account.IsActive,
+	}
+}
+
+func ToServiceAccountResponses(accounts []domain.User) []ServiceAccountResponse {
+	if len(accounts) == 0 {
+		return []ServiceAccountResponse{}
+	}
+
+	responses := make([]ServiceAccountResponse, len(accounts))
+	for i := range accounts {
+		responses[i] = ToServiceAccountResponse(&accounts[i])
+	}
+	return responses
+}
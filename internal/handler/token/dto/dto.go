@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenCreateRequest struct {
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=forms:read forms:write forms:review users:read users:write scim"`
+	ExpiresInDays int      `json:"expiresInDays" validate:"required,min=1,max=365"`
+}
+
+type ServiceAccountCreateRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+	Role string `json:"role" validate:"required,oneof=employee hr admin"`
+}
+
+type TokenResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
+
+// CreatedTokenResponse is the only response that ever contains the token value.
+type CreatedTokenResponse struct {
+	TokenResponse
+	Token string `json:"token"`
+}
+
+type ServiceAccountResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Role     string    `json:"role"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	IsActive bool      `json:"isActive"`
+}
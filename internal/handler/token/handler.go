@@ -0,0 +1,182 @@
+package token
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/middleware"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/handler/token/dto"
+	"github.com/platonso/hrmate/internal/service/token/model"
+)
+
+type Service interface {
+	CreateToken(ctx context.Context, userID uuid.UUID, input *model.TokenCreateInput) (*model.CreatedToken, error)
+	GetTokens(ctx context.Context, userID uuid.UUID) ([]domain.APIToken, error)
+	RevokeToken(ctx context.Context, userID, tokenID uuid.UUID) error
+
+	CreateServiceAccount(ctx context.Context, input *model.ServiceAccountCreateInput) (*domain.User, error)
+	GetServiceAccounts(ctx context.Context) ([]domain.User, error)
+	CreateServiceAccountToken(ctx context.Context, accountID uuid.UUID, input *model.TokenCreateInput) (*model.CreatedToken, error)
+	GetServiceAccountTokens(ctx context.Context, accountID uuid.UUID) ([]domain.APIToken, error)
+	RevokeServiceAccountToken(ctx context.Context, accountID, tokenID uuid.UUID) error
+}
+
+type Handler struct {
+	svc Service
+}
+
+func NewHandler(svc Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) HandleCreateToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.TokenCreateRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	requesterID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	created, err := h.svc.CreateToken(r.Context(), requesterID, dto.ToTokenCreateInput(&req))
+	if err != nil {
+		response.WriteError(w, r, err, "failed to create token")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, dto.ToCreatedTokenResponse(created))
+}
+
+func (h *Handler) HandleGetTokens(w http.ResponseWriter, r *http.Request) {
+	requesterID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	tokens, err := h.svc.GetTokens(r.Context(), requesterID)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to get tokens")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, dto.ToTokenResponses(tokens))
+}
+
+func (h *Handler) HandleRevokeToken(w http.ResponseWriter, r *http.Request) {
+	tokenID, err := uuid.Parse(chi.URLParam(r, "tokenId"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid token id format")
+		return
+	}
+
+	requesterID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	if err := h.svc.RevokeToken(r.Context(), requesterID, tokenID); err != nil {
+		response.WriteError(w, r, err, "failed to revoke token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) HandleCreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req dto.ServiceAccountCreateRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	account, err := h.svc.CreateServiceAccount(r.Context(), dto.ToServiceAccountCreateInput(&req))
+	if err != nil {
+		response.WriteError(w, r, err, "failed to create service account")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, dto.ToServiceAccountResponse(account))
+}
+
+func (h *Handler) HandleGetServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.svc.GetServiceAccounts(r.Context())
+	if err != nil {
+		response.WriteError(w, r, err, "failed to get service accounts")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, dto.ToServiceAccountResponses(accounts))
+}
+
+func (h *Handler) HandleCreateServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid service account id format")
+		return
+	}
+
+	var req dto.TokenCreateRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	created, err := h.svc.CreateServiceAccountToken(r.Context(), accountID, dto.ToTokenCreateInput(&req))
+	if err != nil {
+		response.WriteError(w, r, err, "failed to create token")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, dto.ToCreatedTokenResponse(created))
+}
+
+func (h *Handler) HandleGetServiceAccountTokens(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid service account id format")
+		return
+	}
+
+	tokens, err := h.svc.GetServiceAccountTokens(r.Context(), accountID)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to get tokens")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, dto.ToTokenResponses(tokens))
+}
+
+func (h *Handler) HandleRevokeServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid service account id format")
+		return
+	}
+
+	tokenID, err := uuid.Parse(chi.URLParam(r, "tokenId"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid token id format")
+		return
+	}
+
+	if err := h.svc.RevokeServiceAccountToken(r.Context(), accountID, tokenID); err != nil {
+		response.WriteError(w, r, err, "failed to revoke token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
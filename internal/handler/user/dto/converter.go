@@ -1,9 +1,12 @@
 package dto
 
-import "github.com/platonso/hrmate/internal/domain"
+import (
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/service/user/model"
+)
 
 func ToUserResponse(user *domain.User) UserResponse {
-	return UserResponse{
+	resp := UserResponse{
 		ID:        user.ID,
 		Role:      string(user.Role),
 		FirstName: user.FirstName,
@@ -11,6 +14,45 @@ func ToUserResponse(user *domain.User) UserResponse {
 		Position:  user.Position,
 		Email:     user.Email,
 		IsActive:  user.IsActive,
+
+		IsServiceAccount: user.IsServiceAccount,
+		ManagerID:        user.ManagerID,
+		Version:          user.Version,
+	}
+
+	if user.Locale != "" {
+		resp.Locale = &user.Locale
+	}
+	if user.Department != "" {
+		resp.Department = &user.Department
+	}
+	return resp
+}
+
+func ToCreateUserInput(req CreateUserRequest) model.CreateUserInput {
+	input := model.CreateUserInput{
+		Role:       domain.Role(req.Role),
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Position:   req.Position,
+		Email:      req.Email,
+		Password:   req.Password,
+		Department: req.Department,
+		IsActive:   true,
+	}
+	if req.IsActive != nil {
+		input.IsActive = *req.IsActive
+	}
+	return input
+}
+
+func ToProfileInput(req UpdateUserRequest) model.ProfileInput {
+	return model.ProfileInput{
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Position:   req.Position,
+		Email:      req.Email,
+		Department: req.Department,
 	}
 }
 
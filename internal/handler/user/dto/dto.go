@@ -10,4 +10,56 @@ type UserResponse struct {
 	Position  string    `json:"position"`
 	Email     string    `json:"email"`
 	IsActive  bool      `json:"isActive"`
+
+	IsServiceAccount bool       `json:"isServiceAccount"`
+	Locale           *string    `json:"locale"`
+	Department       *string    `json:"department"`
+	ManagerID        *uuid.UUID `json:"managerId"`
+	Version          int        `json:"version"`
+}
+
+// CreateUserRequest creates an account; it is active unless isActive is false.
+type CreateUserRequest struct {
+	Role       string `json:"role" validate:"required,oneof=employee hr admin"`
+	FirstName  string `json:"firstName" validate:"required,min=2"`
+	LastName   string `json:"lastName" validate:"required,min=2"`
+	Position   string `json:"position" validate:"required,min=2"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required,max=128"`
+	Department string `json:"department" validate:"max=100"`
+	IsActive   *bool  `json:"isActive"`
+}
+
+// UpdateUserRequest changes the fields that are present; an empty department clears it.
+type UpdateUserRequest struct {
+	FirstName  *string `json:"firstName" validate:"omitempty,min=2"`
+	LastName   *string `json:"lastName" validate:"omitempty,min=2"`
+	Position   *string `json:"position" validate:"omitempty,min=2"`
+	Email      *string `json:"email" validate:"omitempty,email"`
+	Department *string `json:"department" validate:"omitempty,max=100"`
+}
+
+type RoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=employee hr admin"`
+}
+
+// LocaleRequest sets the preferred language; an empty locale resets it.
+type LocaleRequest struct {
+	Locale string `json:"locale" validate:"omitempty,oneof=en ru"`
+}
+
+// ImportErrorResponse is an invalid cell of an import file. Field is "rows[<line>].<column>",
+// with the line of the file counting the header.
+type ImportErrorResponse struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param"`
+	Message string `json:"message"`
+}
+
+type ImportReportResponse struct {
+	DryRun  bool                  `json:"dryRun"`
+	Total   int                   `json:"total"`
+	Created int                   `json:"created"`
+	Errors  []ImportErrorResponse `json:"errors"`
 }
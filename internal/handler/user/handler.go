@@ -2,20 +2,34 @@ package user
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/platonso/hrmate/internal/domain"
 	errs "github.com/platonso/hrmate/internal/errors"
 	"github.com/platonso/hrmate/internal/handler/middleware"
+	"github.com/platonso/hrmate/internal/handler/request"
 	"github.com/platonso/hrmate/internal/handler/response"
 	"github.com/platonso/hrmate/internal/handler/user/dto"
+	userservice "github.com/platonso/hrmate/internal/service/user"
+	"github.com/platonso/hrmate/internal/service/user/model"
 )
 
 type Service interface {
-	GetUsersByRole(ctx context.Context, requesterRole domain.Role) ([]domain.User, error)
-	ChangeActiveStatus(ctx context.Context, userID uuid.UUID, newStatus bool) (*domain.User, error)
+	GetUser(ctx context.Context, userID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.User, error)
+	SearchUsers(ctx context.Context, filter *userservice.Filter, requesterRole domain.Role) (*userservice.Page, error)
+	CreateUser(ctx context.Context, input *model.CreateUserInput) (*domain.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, input *model.ProfileInput, expectedVersion *int) (*domain.User, error)
+	ChangeRole(ctx context.Context, userID uuid.UUID, role domain.Role, expectedVersion *int) (*domain.User, error)
+	DeleteUser(ctx context.Context, userID uuid.UUID, expectedVersion *int) error
+	ImportUsers(ctx context.Context, rows []model.ImportRow, opts model.ImportOptions) (*model.ImportResult, error)
+	ExportUsers(ctx context.Context) ([]model.ExportRow, error)
+	ChangeActiveStatus(ctx context.Context, userID uuid.UUID, newStatus bool, expectedVersion *int) (*domain.User, error)
+	ChangeLocale(ctx context.Context, userID uuid.UUID, locale string) (*domain.User, error)
 }
 
 type Handler struct {
@@ -31,17 +45,211 @@ func NewHandler(svc Service) *Handler {
 func (h *Handler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
 	requesterRole, ok := middleware.GetUserRole(r.Context())
 	if !ok {
-		response.WriteError(w, errs.ErrUnauthorized, "authentication required")
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
 		return
 	}
 
-	users, err := h.svc.GetUsersByRole(r.Context(), requesterRole)
+	filter, err := parseFilter(r)
 	if err != nil {
-		response.WriteError(w, err, "failed to get users by role")
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid filter parameters")
 		return
 	}
 
-	response.WriteJSON(w, http.StatusOK, dto.ToUserResponses(users))
+	page, err := h.svc.SearchUsers(r.Context(), filter, requesterRole)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to get users")
+		return
+	}
+
+	// The body stays a plain array, the next page is linked as in RFC 8288
+	if page.Next != nil {
+		next := *r.URL
+		query := next.Query()
+		query.Set("cursor", page.Next.Encode())
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+	}
+
+	response.WriteJSON(w, http.StatusOK, dto.ToUserResponses(page.Users))
+}
+
+// parseFilter reads the query of a user list; sort takes a field name,
+// prefixed with "-" for descending order.
+func parseFilter(r *http.Request) (*userservice.Filter, error) {
+	filter := &userservice.Filter{}
+	query := r.URL.Query()
+
+	filter.Search = query.Get("q")
+
+	if roleStr := query.Get("role"); roleStr != "" {
+		role := domain.Role(roleStr)
+		filter.Role = &role
+	}
+
+	if activeStr := query.Get("is_active"); activeStr != "" {
+		isActive, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid is_active: %s", activeStr)
+		}
+		filter.IsActive = &isActive
+	}
+
+	if department := query.Get("department"); department != "" {
+		filter.Department = &department
+	}
+
+	if sortStr := query.Get("sort"); sortStr != "" {
+		field, desc := strings.CutPrefix(sortStr, "-")
+		filter.Sort = userservice.SortField(field)
+		filter.Desc = desc
+	}
+
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		cursor, err := userservice.ParseCursor(cursorStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		filter.After = cursor
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid limit: %s", limitStr)
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateUserRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	input := dto.ToCreateUserInput(req)
+
+	user, err := h.svc.CreateUser(r.Context(), &input)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to create user")
+		return
+	}
+
+	response.SetETag(w, user.Version)
+	response.WriteJSON(w, http.StatusCreated, dto.ToUserResponse(user))
+}
+
+func (h *Handler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	requesterID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	requesterRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	user, err := h.svc.GetUser(r.Context(), userID, requesterID, requesterRole)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to get user")
+		return
+	}
+
+	response.SetETag(w, user.Version)
+	response.WriteJSON(w, http.StatusOK, dto.ToUserResponse(user))
+}
+
+func (h *Handler) HandleUpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	var req dto.UpdateUserRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		response.WriteError(w, r, err, "the user has been modified")
+		return
+	}
+
+	input := dto.ToProfileInput(req)
+
+	user, err := h.svc.UpdateProfile(r.Context(), userID, &input, expectedVersion)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to update user")
+		return
+	}
+
+	response.SetETag(w, user.Version)
+	response.WriteJSON(w, http.StatusOK, dto.ToUserResponse(user))
+}
+
+func (h *Handler) HandleChangeRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	var req dto.RoleRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		response.WriteError(w, r, err, "the user has been modified")
+		return
+	}
+
+	user, err := h.svc.ChangeRole(r.Context(), userID, domain.Role(req.Role), expectedVersion)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to change user's role")
+		return
+	}
+
+	response.SetETag(w, user.Version)
+	response.WriteJSON(w, http.StatusOK, dto.ToUserResponse(user))
+}
+
+func (h *Handler) HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		response.WriteError(w, r, err, "the user has been modified")
+		return
+	}
+
+	if err := h.svc.DeleteUser(r.Context(), userID, expectedVersion); err != nil {
+		response.WriteError(w, r, err, "failed to delete user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *Handler) HandleActivate(w http.ResponseWriter, r *http.Request) {
@@ -58,14 +266,45 @@ func (h *Handler) handleChangeActiveStatus(
 ) {
 	userID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		response.WriteError(w, errs.ErrInvalidRequest, "invalid user id format")
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid user id format")
+		return
+	}
+
+	expectedVersion, err := request.IfMatch(r)
+	if err != nil {
+		response.WriteError(w, r, err, "the user has been modified")
 		return
 	}
-	user, err := h.svc.ChangeActiveStatus(r.Context(), userID, newStatus)
+
+	user, err := h.svc.ChangeActiveStatus(r.Context(), userID, newStatus, expectedVersion)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to change user's active status")
+		return
+	}
+
+	response.SetETag(w, user.Version)
+	response.WriteJSON(w, http.StatusOK, dto.ToUserResponse(user))
+}
+
+func (h *Handler) HandleChangeLocale(w http.ResponseWriter, r *http.Request) {
+	var req dto.LocaleRequest
+	if err := request.DecodeAndValidate(r, &req); err != nil {
+		response.WriteError(w, r, err, "invalid request format")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, errs.ErrUnauthorized, "authentication required")
+		return
+	}
+
+	user, err := h.svc.ChangeLocale(r.Context(), userID, req.Locale)
 	if err != nil {
-		response.WriteError(w, err, "failed to change user's active status")
+		response.WriteError(w, r, err, "failed to update locale")
 		return
 	}
 
+	response.SetETag(w, user.Version)
 	response.WriteJSON(w, http.StatusOK, dto.ToUserResponse(user))
 }
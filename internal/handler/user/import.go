@@ -0,0 +1,195 @@
+package user
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/handler/request"
+	"github.com/platonso/hrmate/internal/handler/response"
+	"github.com/platonso/hrmate/internal/handler/user/dto"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/service/user/model"
+	"github.com/platonso/hrmate/internal/spreadsheet"
+)
+
+// importColumns are the columns of import and export files, in the order of an export
+var importColumns = []string{"email", "first_name", "last_name", "role", "position", "department", "manager_email"}
+
+var requiredColumns = []string{"email", "first_name", "last_name"}
+
+// maxUploadMemory is how much of an upload is kept in memory, the rest goes to a temporary file
+const maxUploadMemory = 8 << 20
+
+// HandleImportUsers creates the users of an uploaded CSV or XLSX file. With dry_run
+// the rows are only validated; otherwise either all users are created or none.
+func (h *Handler) HandleImportUsers(w http.ResponseWriter, r *http.Request) {
+	var opts model.ImportOptions
+	for param, dest := range map[string]*bool{
+		"dry_run": &opts.DryRun,
+		"invite":  &opts.Invite,
+	} {
+		if value := r.URL.Query().Get(param); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				response.WriteError(w, r, errs.ErrInvalidRequest, "invalid import parameters")
+				return
+			}
+			*dest = parsed
+		}
+	}
+
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		if request.IsBodyTooLarge(err) {
+			response.WriteError(w, r, errs.ErrBodyTooLarge, "request body is too large")
+			return
+		}
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid import file")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid import file")
+		return
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	head, _ := reader.Peek(4)
+
+	records, err := spreadsheet.Read(reader, spreadsheet.DetectFormat(header.Filename, head))
+	if err != nil {
+		logger.FromContext(r.Context()).Info("failed to read import file", "error", err)
+		response.WriteError(w, r, errs.ErrInvalidRequest, "invalid import file")
+		return
+	}
+
+	rows, err := toImportRows(r, records)
+	if err != nil {
+		response.WriteError(w, r, err, "invalid import file")
+		return
+	}
+
+	result, err := h.svc.ImportUsers(r.Context(), rows, opts)
+	if err != nil {
+		response.WriteError(w, r, err, "failed to import users")
+		return
+	}
+
+	details := make([]errs.FieldError, len(result.Errors))
+	for i, e := range result.Errors {
+		details[i] = request.NewFieldError(r, fmt.Sprintf("rows[%d].%s", e.Line, e.Column), e.Rule, e.Param, "")
+	}
+
+	// Nothing has been created, the file has to be fixed as a whole
+	if !opts.DryRun && len(details) > 0 {
+		response.WriteError(w, r, errs.NewValidationError(details...), "invalid import file")
+		return
+	}
+
+	report := dto.ImportReportResponse{
+		DryRun:  opts.DryRun,
+		Total:   result.Total,
+		Created: result.Created,
+		Errors:  make([]dto.ImportErrorResponse, len(details)),
+	}
+	for i, d := range details {
+		report.Errors[i] = dto.ImportErrorResponse{Field: d.Field, Rule: d.Rule, Param: d.Param, Message: d.Message}
+	}
+
+	status := http.StatusCreated
+	if opts.DryRun {
+		status = http.StatusOK
+	}
+	response.WriteJSON(w, status, report)
+}
+
+// toImportRows maps the rows of the file by the column names of its header,
+// which may come in any order.
+func toImportRows(r *http.Request, records [][]string) ([]model.ImportRow, error) {
+	if len(records) < 2 {
+		return nil, errs.NewValidationError(request.NewFieldError(r, "file", "min", "1", request.KindList))
+	}
+
+	var details []errs.FieldError
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(importColumns, name) {
+			details = append(details, request.NewFieldError(r, "header."+name, "oneof", strings.Join(importColumns, " "), ""))
+			continue
+		}
+		columns[name] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			details = append(details, request.NewFieldError(r, "header."+name, "required", "", ""))
+		}
+	}
+	if len(details) > 0 {
+		return nil, errs.NewValidationError(details...)
+	}
+
+	cell := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	rows := make([]model.ImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		rows = append(rows, model.ImportRow{
+			// Line 1 is the header
+			Line:         i + 2,
+			Email:        cell(record, "email"),
+			FirstName:    cell(record, "first_name"),
+			LastName:     cell(record, "last_name"),
+			Role:         cell(record, "role"),
+			Position:     cell(record, "position"),
+			Department:   cell(record, "department"),
+			ManagerEmail: cell(record, "manager_email"),
+		})
+	}
+
+	return rows, nil
+}
+
+// HandleExportUsers downloads all users in the columns of an import, as CSV unless format=xlsx.
+func (h *Handler) HandleExportUsers(w http.ResponseWriter, r *http.Request) {
+	format := spreadsheet.CSV
+	if formatStr := r.URL.Query().Get("format"); formatStr != "" {
+		var err error
+		if format, err = spreadsheet.ParseFormat(formatStr); err != nil {
+			response.WriteError(w, r, errs.ErrInvalidRequest, "invalid export format")
+			return
+		}
+	}
+
+	users, err := h.svc.ExportUsers(r.Context())
+	if err != nil {
+		response.WriteError(w, r, err, "failed to export users")
+		return
+	}
+
+	records := make([][]string, 0, len(users)+1)
+	records = append(records, importColumns)
+	for _, u := range users {
+		records = append(records, []string{u.Email, u.FirstName, u.LastName, string(u.Role), u.Position, u.Department, u.ManagerEmail})
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, format))
+	if err := spreadsheet.Write(w, format, records); err != nil {
+		logger.FromContext(r.Context()).Error("failed to write export", "error", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Package i18n provides message catalogs for the languages hrmate speaks.
+//
+// A message is looked up in the requested locale, then in its base language
+// (ru-RU -> ru) and finally in DefaultLocale.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+const DefaultLocale = "en"
+
+const localeKey = "locale"
+
+//go:embed locales/*.json
+var catalogFiles embed.FS
+
+var (
+	catalogs = mustLoadCatalogs()
+
+	supportedTags = []language.Tag{language.English, language.Russian}
+	matcher       = language.NewMatcher(supportedTags)
+)
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := catalogFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("read message catalogs: %v", err))
+	}
+
+	result := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := catalogFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("read message catalog %s: %v", entry.Name(), err))
+		}
+
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("parse message catalog %s: %v", entry.Name(), err))
+		}
+
+		result[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+
+	return result
+}
+
+// Supported reports whether the locale has a catalog and returns its canonical form.
+func Supported(locale string) (string, bool) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", false
+	}
+
+	base, _ := tag.Base()
+	if _, ok := catalogs[base.String()]; !ok {
+		return "", false
+	}
+	return base.String(), true
+}
+
+// Locales lists the supported locales.
+func Locales() []string {
+	locales := make([]string, 0, len(supportedTags))
+	for _, tag := range supportedTags {
+		locales = append(locales, tag.String())
+	}
+	return locales
+}
+
+// Match picks the supported locale that best matches an Accept-Language header.
+func Match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return supportedTags[index].String()
+}
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// FromContext returns the locale of the request, DefaultLocale if none was set.
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Lookup returns the message for the key following the fallback chain.
+func Lookup(locale, key string) (string, bool) {
+	for _, candidate := range fallbackChain(locale) {
+		if message, ok := catalogs[candidate][key]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// T translates the key and substitutes "{name}" placeholders. Unknown keys are returned as is.
+func T(locale, key string, params ...string) string {
+	message, ok := Lookup(locale, key)
+	if !ok {
+		return key
+	}
+
+	if len(params) > 0 {
+		pairs := make([]string, 0, len(params))
+		for i := 0; i+1 < len(params); i += 2 {
+			pairs = append(pairs, "{"+params[i]+"}", params[i+1])
+		}
+		message = strings.NewReplacer(pairs...).Replace(message)
+	}
+
+	return message
+}
+
+func fallbackChain(locale string) []string {
+	chain := make([]string, 0, 3)
+	if locale != "" {
+		chain = append(chain, locale)
+	}
+
+	if base, _, found := strings.Cut(locale, "-"); found {
+		chain = append(chain, base)
+	}
+
+	if locale != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+
+	return chain
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/service/assignment"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// scrapeTimeout bounds the database queries made while collecting metrics.
+const scrapeTimeout = 5 * time.Second
+
+// PoolCollector reports the statistics of the database connection pool.
+type PoolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	constructingConns *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	emptyAcquires     *prometheus.Desc
+	newConns          *prometheus.Desc
+	lifetimeDestroys  *prometheus.Desc
+	idleDestroys      *prometheus.Desc
+}
+
+func NewPoolCollector(stat func() *pgxpool.Stat) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &PoolCollector{
+		stat: stat,
+
+		acquiredConns:     desc("acquired_connections", "Connections currently in use."),
+		idleConns:         desc("idle_connections", "Idle connections."),
+		constructingConns: desc("constructing_connections", "Connections being established."),
+		totalConns:        desc("total_connections", "All open connections."),
+		maxConns:          desc("max_connections", "Maximum size of the pool."),
+		acquireCount:      desc("acquires_total", "Successful acquires from the pool."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		canceledAcquires:  desc("canceled_acquires_total", "Acquires canceled by their context."),
+		emptyAcquires:     desc("empty_acquires_total", "Acquires that had to wait for a connection."),
+		newConns:          desc("new_connections_total", "Connections opened."),
+		lifetimeDestroys:  desc("max_lifetime_destroys_total", "Connections closed for exceeding their lifetime."),
+		idleDestroys:      desc("max_idle_destroys_total", "Connections closed for being idle too long."),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(c.acquiredConns, float64(s.AcquiredConns()))
+	gauge(c.idleConns, float64(s.IdleConns()))
+	gauge(c.constructingConns, float64(s.ConstructingConns()))
+	gauge(c.totalConns, float64(s.TotalConns()))
+	gauge(c.maxConns, float64(s.MaxConns()))
+	counter(c.acquireCount, float64(s.AcquireCount()))
+	counter(c.acquireDuration, s.AcquireDuration().Seconds())
+	counter(c.canceledAcquires, float64(s.CanceledAcquireCount()))
+	counter(c.emptyAcquires, float64(s.EmptyAcquireCount()))
+	counter(c.newConns, float64(s.NewConnsCount()))
+	counter(c.lifetimeDestroys, float64(s.MaxLifetimeDestroyCount()))
+	counter(c.idleDestroys, float64(s.MaxIdleDestroyCount()))
+}
+
+type WorkloadRepository interface {
+	FindActiveHRsWithWorkload(ctx context.Context) ([]assignment.HRWorkload, error)
+}
+
+// WorkloadCollector reports the pending requests of every active HR, queried on scrape.
+type WorkloadCollector struct {
+	repo    WorkloadRepository
+	pending *prometheus.Desc
+}
+
+func NewWorkloadCollector(repo WorkloadRepository) *WorkloadCollector {
+	return &WorkloadCollector{
+		repo: repo,
+		pending: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "forms", "pending"),
+			"Pending requests assigned to each active HR.",
+			[]string{"hr_id"}, nil,
+		),
+	}
+}
+
+func (c *WorkloadCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pending
+}
+
+func (c *WorkloadCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	hrs, err := c.repo.FindActiveHRsWithWorkload(ctx)
+	if err != nil {
+		slog.Error("failed to collect HR workload", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.pending, err)
+		return
+	}
+
+	for _, hr := range hrs {
+		ch <- prometheus.MustNewConstMetric(c.pending, prometheus.GaugeValue, float64(hr.PendingFormsCount), hr.UserID.String())
+	}
+}
@@ -0,0 +1,125 @@
+// Package metrics exposes HTTP, database and business metrics in the Prometheus format.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "hrmate"
+
+// unmatchedRoute labels requests that did not match any route, so unknown
+// paths cannot blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	formsCreated    prometheus.Counter
+	formsReviewed   *prometheus.CounterVec
+	decisionLatency *prometheus.HistogramVec
+}
+
+// New registers the metrics together with the Go runtime and process collectors.
+// Extra collectors, e.g. for the connection pool, are registered as well.
+func New(extra ...prometheus.Collector) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, chi route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method and chi route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+
+		formsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "forms",
+			Name:      "created_total",
+			Help:      "Submitted requests.",
+		}),
+
+		formsReviewed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "forms",
+			Name:      "reviewed_total",
+			Help:      "Reviewed requests by decision (approved or rejected).",
+		}, []string{"status"}),
+
+		decisionLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "forms",
+			Name:      "time_to_decision_seconds",
+			Help:      "Time from submitting a request to its review, by decision.",
+			Buckets: []float64{
+				(time.Minute).Seconds(),
+				(10 * time.Minute).Seconds(),
+				(time.Hour).Seconds(),
+				(4 * time.Hour).Seconds(),
+				(24 * time.Hour).Seconds(),
+				(3 * 24 * time.Hour).Seconds(),
+				(7 * 24 * time.Hour).Seconds(),
+				(14 * 24 * time.Hour).Seconds(),
+			},
+		}, []string{"status"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.formsCreated,
+		m.formsReviewed,
+		m.decisionLatency,
+	)
+	m.registry.MustRegister(extra...)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (m *Metrics) FormCreated() {
+	m.formsCreated.Inc()
+}
+
+// FormReviewed counts the decision and records how long the request waited for it.
+func (m *Metrics) FormReviewed(form *domain.Form) {
+	status := string(form.Status)
+	m.formsReviewed.WithLabelValues(status).Inc()
+
+	if form.ReviewedAt != nil {
+		m.decisionLatency.WithLabelValues(status).Observe(form.ReviewedAt.Sub(form.CreatedAt).Seconds())
+	}
+}
@@ -0,0 +1,50 @@
+package password
+
+import "errors"
+
+var ErrUnknownHash = errors.New("unknown password hash format")
+
+// Algorithm is a single password hashing scheme.
+type Algorithm interface {
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the hash and whether the hash
+	// was produced with parameters weaker than the configured ones.
+	Verify(password, encoded string) (match bool, outdated bool, err error)
+	// Supports reports whether the encoded hash was produced by this algorithm.
+	Supports(encoded string) bool
+}
+
+// Hasher hashes new passwords with the current algorithm and still verifies hashes
+// of legacy algorithms, so stored hashes can be upgraded on the next successful login.
+type Hasher struct {
+	current Algorithm
+	legacy  []Algorithm
+}
+
+func NewHasher(current Algorithm, legacy ...Algorithm) *Hasher {
+	return &Hasher{
+		current: current,
+		legacy:  legacy,
+	}
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// Verify checks the password. needsRehash is true when the password matched but
+// the stored hash should be replaced with one from Hash.
+func (h *Hasher) Verify(password, encoded string) (match bool, needsRehash bool, err error) {
+	if h.current.Supports(encoded) {
+		return h.current.Verify(password, encoded)
+	}
+
+	for _, alg := range h.legacy {
+		if alg.Supports(encoded) {
+			match, _, err := alg.Verify(password, encoded)
+			return match, match, err
+		}
+	}
+
+	return false, false, ErrUnknownHash
+}
@@ -0,0 +1,83 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	errs "github.com/platonso/hrmate/internal/errors"
+)
+
+// commonPasswords is a short built-in list used even when no breached list file is configured.
+//
+//go:embed common.txt
+var commonPasswords string
+
+type Policy struct {
+	minLength int
+	breached  map[string]struct{}
+}
+
+// NewPolicy creates a password policy. breachedListFile is an optional path to a
+// newline-separated list of known breached passwords.
+func NewPolicy(minLength int, breachedListFile string) (*Policy, error) {
+	p := &Policy{
+		minLength: minLength,
+		breached:  make(map[string]struct{}),
+	}
+
+	if err := p.load(strings.NewReader(commonPasswords)); err != nil {
+		return nil, fmt.Errorf("load built-in password list: %w", err)
+	}
+
+	if breachedListFile != "" {
+		f, err := os.Open(breachedListFile)
+		if err != nil {
+			return nil, fmt.Errorf("open breached password list: %w", err)
+		}
+		defer f.Close()
+
+		if err := p.load(f); err != nil {
+			return nil, fmt.Errorf("load breached password list: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// Validate checks the password against the policy for the account with the given email.
+func (p *Policy) Validate(password, email string) error {
+	if utf8.RuneCountInString(password) < p.minLength {
+		return errs.ErrPasswordTooShort
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(password))
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	localPart, _, _ := strings.Cut(email, "@")
+	if email != "" && (normalized == email || normalized == localPart) {
+		return errs.ErrPasswordMatchesEmail
+	}
+
+	if _, ok := p.breached[normalized]; ok {
+		return errs.ErrPasswordBreached
+	}
+
+	return nil
+}
+
+func (p *Policy) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.breached[line] = struct{}{}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToAuditRecord(e domain.AuditEntry) AuditRecord {
+	return AuditRecord{
+		ID:         e.ID,
+		ActorID:    e.ActorID,
+		SubjectID:  e.SubjectID,
+		ResourceID: e.ResourceID,
+		Method:     e.Method,
+		Route:      e.Route,
+		Path:       e.Path,
+		StatusCode: e.StatusCode,
+		IPAddress:  e.IPAddress,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func ToDomainAuditEntry(ar AuditRecord) domain.AuditEntry {
+	return domain.AuditEntry{
+		ID:         ar.ID,
+		ActorID:    ar.ActorID,
+		SubjectID:  ar.SubjectID,
+		ResourceID: ar.ResourceID,
+		Method:     ar.Method,
+		Route:      ar.Route,
+		Path:       ar.Path,
+		StatusCode: ar.StatusCode,
+		IPAddress:  ar.IPAddress,
+		CreatedAt:  ar.CreatedAt,
+	}
+}
+
+func ToDomainAuditEntries(records []AuditRecord) []domain.AuditEntry {
+	entries := make([]domain.AuditEntry, len(records))
+	for i := range records {
+		entries[i] = ToDomainAuditEntry(records[i])
+	}
+	return entries
+}
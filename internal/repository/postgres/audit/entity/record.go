@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditRecord struct {
+	ID         uuid.UUID  `db:"id"`
+	ActorID    uuid.UUID  `db:"actor_id"`
+	SubjectID  *uuid.UUID `db:"subject_id"`
+	ResourceID *uuid.UUID `db:"resource_id"`
+	Method     string     `db:"method"`
+	Route      string     `db:"route"`
+	Path       string     `db:"path"`
+	StatusCode int        `db:"status_code"`
+	IPAddress  string     `db:"ip_address"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
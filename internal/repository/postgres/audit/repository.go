@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/repository/postgres/audit/entity"
+	auditservice "github.com/platonso/hrmate/internal/service/audit"
+)
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	rec := entity.ToAuditRecord(*entry)
+	query := `
+		INSERT INTO audit_log (id, actor_id, subject_id, resource_id, method, route, path, status_code, ip_address, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(
+		ctx,
+		query,
+		rec.ID,
+		rec.ActorID,
+		rec.SubjectID,
+		rec.ResourceID,
+		rec.Method,
+		rec.Route,
+		rec.Path,
+		rec.StatusCode,
+		rec.IPAddress,
+		rec.CreatedAt,
+	)
+	return err
+}
+
+func (r *Repository) FindByFilter(ctx context.Context, filter *auditservice.Filter) ([]domain.AuditEntry, error) {
+	query := `SELECT id, actor_id, subject_id, resource_id, method, route, path, status_code, ip_address, created_at FROM audit_log`
+	var conditions []string
+	var args []any
+	argPos := 1
+
+	if filter.ActorID != nil {
+		conditions = append(conditions, fmt.Sprintf("actor_id = $%d", argPos))
+		args = append(args, *filter.ActorID)
+		argPos++
+	}
+
+	if filter.SubjectID != nil {
+		conditions = append(conditions, fmt.Sprintf("subject_id = $%d", argPos))
+		args = append(args, *filter.SubjectID)
+		argPos++
+	}
+
+	if filter.InvolvedUserID != nil {
+		conditions = append(conditions, fmt.Sprintf("(actor_id = $%d OR subject_id = $%d OR resource_id = $%d)", argPos, argPos, argPos))
+		args = append(args, *filter.InvolvedUserID)
+		argPos++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argPos)
+		args = append(args, filter.Limit)
+	}
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query audit log: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.AuditRecord])
+	if err != nil {
+		return nil, fmt.Errorf("collect audit log: %w", err)
+	}
+
+	return entity.ToDomainAuditEntries(records), nil
+}
+
+// AnonymizeByActor removes the IP addresses of the requests the user made. The
+// entries themselves are kept, the audit trail must stay complete.
+func (r *Repository) AnonymizeByActor(ctx context.Context, actorID uuid.UUID) (int64, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `UPDATE audit_log SET ip_address = '' WHERE actor_id = $1 AND ip_address <> ''`, actorID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
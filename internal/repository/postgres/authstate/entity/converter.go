@@ -0,0 +1,21 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToAuthStateRecord(s domain.AuthState) AuthStateRecord {
+	return AuthStateRecord{
+		State:        s.State,
+		CodeVerifier: s.CodeVerifier,
+		Nonce:        s.Nonce,
+		ExpiresAt:    s.ExpiresAt,
+	}
+}
+
+func ToDomainAuthState(sr AuthStateRecord) domain.AuthState {
+	return domain.AuthState{
+		State:        sr.State,
+		CodeVerifier: sr.CodeVerifier,
+		Nonce:        sr.Nonce,
+		ExpiresAt:    sr.ExpiresAt,
+	}
+}
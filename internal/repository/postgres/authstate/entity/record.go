@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+type AuthStateRecord struct {
+	State        string    `db:"state"`
+	CodeVerifier string    `db:"code_verifier"`
+	Nonce        string    `db:"nonce"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
@@ -0,0 +1,70 @@
+package authstate
+
+import (
+	"context"
+	"errors"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/repository/postgres/authstate/entity"
+)
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+// Create stores a new pending state and purges the ones that have already expired.
+func (r *Repository) Create(ctx context.Context, state *domain.AuthState) error {
+	rec := entity.ToAuthStateRecord(*state)
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	if _, err := conn.Exec(ctx, `DELETE FROM oidc_states WHERE expires_at < now()`); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO oidc_states (state, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4)
+`
+	_, err := conn.Exec(ctx, query, rec.State, rec.CodeVerifier, rec.Nonce, rec.ExpiresAt)
+	return err
+}
+
+// Consume deletes the state and returns it, so every state can be redeemed only once.
+func (r *Repository) Consume(ctx context.Context, state string) (*domain.AuthState, error) {
+	query := `
+		DELETE FROM oidc_states
+		WHERE state = $1
+		RETURNING state, code_verifier, nonce, expires_at
+`
+	var rec entity.AuthStateRecord
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	err := conn.QueryRow(ctx, query, state).Scan(
+		&rec.State,
+		&rec.CodeVerifier,
+		&rec.Nonce,
+		&rec.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrInvalidAuthState
+		}
+		return nil, err
+	}
+
+	authState := entity.ToDomainAuthState(rec)
+	return &authState, nil
+}
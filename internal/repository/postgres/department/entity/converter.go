@@ -0,0 +1,27 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToDepartmentRecord(d domain.Department) DepartmentRecord {
+	return DepartmentRecord{
+		ID:        d.ID,
+		Name:      d.Name,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func ToDomainDepartment(dr DepartmentRecord) domain.Department {
+	return domain.Department{
+		ID:        dr.ID,
+		Name:      dr.Name,
+		CreatedAt: dr.CreatedAt,
+	}
+}
+
+func ToDomainDepartments(records []DepartmentRecord) []domain.Department {
+	departments := make([]domain.Department, len(records))
+	for i := range records {
+		departments[i] = ToDomainDepartment(records[i])
+	}
+	return departments
+}
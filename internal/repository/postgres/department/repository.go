@@ -0,0 +1,129 @@
+package department
+
+import (
+	"context"
+	"errors"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/repository/postgres/department/entity"
+)
+
+const uniqueViolation = "23505"
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, department *domain.Department) error {
+	rec := entity.ToDepartmentRecord(*department)
+	query := `
+		INSERT INTO departments (id, name, created_at)
+		VALUES ($1, $2, $3)
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, query, rec.ID, rec.Name, rec.CreatedAt)
+	if isUniqueViolation(err) {
+		return errs.ErrDepartmentAlreadyExists
+	}
+	return err
+}
+
+func (r *Repository) FindAll(ctx context.Context) ([]domain.Department, error) {
+	query := `
+		SELECT id, name, created_at
+		FROM departments
+		ORDER BY name
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DepartmentRecord])
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ToDomainDepartments(records), nil
+}
+
+func (r *Repository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Department, error) {
+	return r.findDepartment(ctx, `SELECT id, name, created_at FROM departments WHERE id = $1`, id)
+}
+
+func (r *Repository) FindByName(ctx context.Context, name string) (*domain.Department, error) {
+	return r.findDepartment(ctx, `SELECT id, name, created_at FROM departments WHERE name = $1`, name)
+}
+
+// Update renames the department. The users of the department are not changed.
+func (r *Repository) Update(ctx context.Context, department *domain.Department) error {
+	rec := entity.ToDepartmentRecord(*department)
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `UPDATE departments SET name = $1 WHERE id = $2`, rec.Name, rec.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errs.ErrDepartmentAlreadyExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM departments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+func (r *Repository) findDepartment(ctx context.Context, query string, arg any) (*domain.Department, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[entity.DepartmentRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrDepartmentNotFound
+		}
+		return nil, err
+	}
+
+	department := entity.ToDomainDepartment(rec)
+	return &department, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -17,6 +17,7 @@ func ToFormRecord(f domain.Form) FormRecord {
 
 		Status:  string(f.Status),
 		Comment: f.Comment,
+		Version: f.Version,
 	}
 }
 
@@ -35,6 +36,7 @@ func ToDomainForm(fr FormRecord) domain.Form {
 
 		Status:  domain.FormStatus(fr.Status),
 		Comment: fr.Comment,
+		Version: fr.Version,
 	}
 }
 
@@ -20,4 +20,5 @@ type FormRecord struct {
 	ReviewedAt *time.Time `db:"reviewed_at"`
 	Status     string     `db:"status"`
 	Comment    *string    `db:"comment"`
+	Version    int        `db:"version"`
 }
@@ -31,8 +31,8 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 func (r *Repository) Create(ctx context.Context, form *domain.Form) error {
 	rec := entity.ToFormRecord(*form)
 	query := `
-		INSERT INTO forms (id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO forms (id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 `
 	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
 
@@ -50,13 +50,14 @@ func (r *Repository) Create(ctx context.Context, form *domain.Form) error {
 		rec.ReviewedAt,
 		rec.Status,
 		rec.Comment,
+		rec.Version,
 	)
 	return err
 }
 
 func (r *Repository) FindAll(ctx context.Context) ([]domain.Form, error) {
 	query := `
-        SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment
+        SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment, version
         FROM forms
         ORDER BY created_at DESC
     `
@@ -71,7 +72,7 @@ func (r *Repository) FindAll(ctx context.Context) ([]domain.Form, error) {
 
 func (r *Repository) FindByFormID(ctx context.Context, formId uuid.UUID) (*domain.Form, error) {
 	query := `
-		SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment
+		SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment, version
 		FROM forms
 		WHERE id = $1
 `
@@ -85,7 +86,7 @@ func (r *Repository) FindByFormID(ctx context.Context, formId uuid.UUID) (*domai
 
 func (r *Repository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Form, error) {
 	query := `
-        SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment
+        SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment, version
         FROM forms 
         WHERE user_id = $1
         ORDER BY created_at DESC
@@ -100,7 +101,7 @@ func (r *Repository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]doma
 }
 
 func (r *Repository) FindByFilter(ctx context.Context, filter *formservice.Filter) ([]domain.Form, error) {
-	query := `SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment FROM forms`
+	query := `SELECT id, user_id, executor_id, title, description, start_date, end_date, created_at, reviewed_at, status, comment, version FROM forms`
 	var conditions []string
 	var args []any
 	argPos := 1
@@ -112,6 +113,12 @@ func (r *Repository) FindByFilter(ctx context.Context, filter *formservice.Filte
 			argPos++
 		}
 
+		if len(filter.UserIDs) > 0 {
+			conditions = append(conditions, fmt.Sprintf("user_id = ANY($%d)", argPos))
+			args = append(args, filter.UserIDs)
+			argPos++
+		}
+
 		if filter.ExecutorID != nil {
 			conditions = append(conditions, fmt.Sprintf("executor_id = $%d", argPos))
 			args = append(args, *filter.ExecutorID)
@@ -139,27 +146,54 @@ func (r *Repository) FindByFilter(ctx context.Context, filter *formservice.Filte
 	return entity.ToDomainForms(records), nil
 }
 
+// Update saves the form only if it has not been changed since it was read,
+// otherwise errs.ErrPreconditionFailed is returned. On success the version is incremented.
 func (r *Repository) Update(ctx context.Context, form *domain.Form) error {
 	rec := entity.ToFormRecord(*form)
 	query := `
 	UPDATE forms 
-	SET reviewed_at = $1, status = $2, comment = $3
-	WHERE id = $4`
+	SET executor_id = $1, reviewed_at = $2, status = $3, comment = $4, version = version + 1
+	WHERE id = $5 AND version = $6`
 
 	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
 
-	tag, err := conn.Exec(ctx, query, rec.ReviewedAt, rec.Status, rec.Comment, rec.ID)
+	tag, err := conn.Exec(ctx, query, rec.ExecutorID, rec.ReviewedAt, rec.Status, rec.Comment, rec.ID, rec.Version)
 	if err != nil {
 		return err
 	}
 
 	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := conn.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM forms WHERE id = $1)`, rec.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return errs.ErrPreconditionFailed
+		}
 		return errs.ErrFormNotFound
 	}
 
+	form.Version++
 	return nil
 }
 
+// EraseByUserID replaces the free text of the forms the user authored, keeping their
+// status, dates and reviewer for statistics, and returns how many were erased.
+func (r *Repository) EraseByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+	UPDATE forms
+	SET title = $1, description = NULL, comment = NULL, version = version + 1
+	WHERE user_id = $2`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, query, domain.ErasedFormTitle, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
 func (r *Repository) findForm(ctx context.Context, query string, args ...any) (entity.FormRecord, error) {
 	var rec entity.FormRecord
 	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
@@ -175,6 +209,7 @@ func (r *Repository) findForm(ctx context.Context, query string, args ...any) (e
 		&rec.ReviewedAt,
 		&rec.Status,
 		&rec.Comment,
+		&rec.Version,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
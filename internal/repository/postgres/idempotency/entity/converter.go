@@ -0,0 +1,45 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToIdempotencyKeyRecord(k domain.IdempotencyKey) IdempotencyKeyRecord {
+	record := IdempotencyKeyRecord{
+		UserID:       k.UserID,
+		Key:          k.Key,
+		Method:       k.Method,
+		Path:         k.Path,
+		Fingerprint:  k.Fingerprint,
+		ResponseBody: k.ResponseBody,
+		CreatedAt:    k.CreatedAt,
+		CompletedAt:  k.CompletedAt,
+		ExpiresAt:    k.ExpiresAt,
+	}
+
+	if k.IsCompleted() {
+		record.StatusCode = &k.StatusCode
+		record.ContentType = &k.ContentType
+	}
+	return record
+}
+
+func ToDomainIdempotencyKey(kr IdempotencyKeyRecord) domain.IdempotencyKey {
+	key := domain.IdempotencyKey{
+		UserID:       kr.UserID,
+		Key:          kr.Key,
+		Method:       kr.Method,
+		Path:         kr.Path,
+		Fingerprint:  kr.Fingerprint,
+		ResponseBody: kr.ResponseBody,
+		CreatedAt:    kr.CreatedAt,
+		CompletedAt:  kr.CompletedAt,
+		ExpiresAt:    kr.ExpiresAt,
+	}
+
+	if kr.StatusCode != nil {
+		key.StatusCode = *kr.StatusCode
+	}
+	if kr.ContentType != nil {
+		key.ContentType = *kr.ContentType
+	}
+	return key
+}
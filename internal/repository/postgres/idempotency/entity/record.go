@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyKeyRecord struct {
+	UserID      uuid.UUID `db:"user_id"`
+	Key         string    `db:"idempotency_key"`
+	Method      string    `db:"method"`
+	Path        string    `db:"path"`
+	Fingerprint string    `db:"fingerprint"`
+
+	StatusCode   *int    `db:"status_code"`
+	ContentType  *string `db:"content_type"`
+	ResponseBody []byte  `db:"response_body"`
+
+	CreatedAt   time.Time  `db:"created_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+}
@@ -0,0 +1,152 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/repository/postgres/idempotency/entity"
+)
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+// Reserve stores the key unless a live entry already exists. It returns true when
+// the caller owns the key, otherwise the existing entry is returned as well.
+// Expired entries and requests abandoned before staleBefore are taken over.
+func (r *Repository) Reserve(ctx context.Context, key *domain.IdempotencyKey, staleBefore time.Time) (bool, *domain.IdempotencyKey, error) {
+	rec := entity.ToIdempotencyKeyRecord(*key)
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	query := `
+		INSERT INTO idempotency_keys (user_id, idempotency_key, method, path, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, idempotency_key) DO UPDATE SET
+			method = EXCLUDED.method,
+			path = EXCLUDED.path,
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < EXCLUDED.created_at
+			OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.created_at < $8)
+`
+	tag, err := conn.Exec(ctx, query,
+		rec.UserID,
+		rec.Key,
+		rec.Method,
+		rec.Path,
+		rec.Fingerprint,
+		rec.CreatedAt,
+		rec.ExpiresAt,
+		staleBefore,
+	)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if tag.RowsAffected() == 1 {
+		return true, nil, nil
+	}
+
+	existing, err := r.find(ctx, key.UserID, key.Key)
+	if err != nil {
+		return false, nil, err
+	}
+	return false, existing, nil
+}
+
+// Complete stores the response of a reserved key.
+func (r *Repository) Complete(ctx context.Context, key *domain.IdempotencyKey) error {
+	rec := entity.ToIdempotencyKeyRecord(*key)
+	query := `
+		UPDATE idempotency_keys SET
+			status_code = $1,
+			content_type = $2,
+			response_body = $3,
+			completed_at = $4
+		WHERE user_id = $5 AND idempotency_key = $6
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, query,
+		rec.StatusCode,
+		rec.ContentType,
+		rec.ResponseBody,
+		rec.CompletedAt,
+		rec.UserID,
+		rec.Key,
+	)
+	return err
+}
+
+func (r *Repository) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, `DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2`, userID, key)
+	return err
+}
+
+func (r *Repository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at < $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *Repository) find(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	query := `
+		SELECT user_id, idempotency_key, method, path, fingerprint, status_code, content_type,
+		       response_body, created_at, completed_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, userID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.IdempotencyKeyRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Deleted between the insert attempt and the lookup: the key is free again
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := entity.ToDomainIdempotencyKey(rec)
+	return &result, nil
+}
+
+func (r *Repository) DeleteByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM idempotency_keys WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToIdentityRecord(i domain.Identity) IdentityRecord {
+	return IdentityRecord{
+		Issuer:    i.Issuer,
+		Subject:   i.Subject,
+		UserID:    i.UserID,
+		Email:     i.Email,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+func ToDomainIdentity(ir IdentityRecord) domain.Identity {
+	return domain.Identity{
+		Issuer:    ir.Issuer,
+		Subject:   ir.Subject,
+		UserID:    ir.UserID,
+		Email:     ir.Email,
+		CreatedAt: ir.CreatedAt,
+	}
+}
@@ -0,0 +1,76 @@
+package identity
+
+import (
+	"context"
+	"errors"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/repository/postgres/identity/entity"
+)
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, identity *domain.Identity) error {
+	rec := entity.ToIdentityRecord(*identity)
+	query := `
+		INSERT INTO user_identities (issuer, subject, user_id, email, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, query, rec.Issuer, rec.Subject, rec.UserID, rec.Email, rec.CreatedAt)
+	return err
+}
+
+func (r *Repository) FindByIssuerSubject(ctx context.Context, issuer, subject string) (*domain.Identity, error) {
+	query := `
+		SELECT issuer, subject, user_id, email, created_at
+		FROM user_identities
+		WHERE issuer = $1 AND subject = $2
+`
+	var rec entity.IdentityRecord
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	err := conn.QueryRow(ctx, query, issuer, subject).Scan(
+		&rec.Issuer,
+		&rec.Subject,
+		&rec.UserID,
+		&rec.Email,
+		&rec.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrIdentityNotFound
+		}
+		return nil, err
+	}
+
+	identity := entity.ToDomainIdentity(rec)
+	return &identity, nil
+}
+
+func (r *Repository) DeleteByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM user_identities WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
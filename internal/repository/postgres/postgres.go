@@ -6,37 +6,69 @@ import (
 	"time"
 
 	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/repository/postgres/audit"
+	"github.com/platonso/hrmate/internal/repository/postgres/authstate"
+	"github.com/platonso/hrmate/internal/repository/postgres/department"
 	"github.com/platonso/hrmate/internal/repository/postgres/form"
+	"github.com/platonso/hrmate/internal/repository/postgres/idempotency"
+	"github.com/platonso/hrmate/internal/repository/postgres/identity"
+	"github.com/platonso/hrmate/internal/repository/postgres/ratelimit"
+	"github.com/platonso/hrmate/internal/repository/postgres/session"
+	"github.com/platonso/hrmate/internal/repository/postgres/token"
 	"github.com/platonso/hrmate/internal/repository/postgres/user"
+	"github.com/platonso/hrmate/internal/tracing"
 )
 
 type Repository struct {
-	Users *user.Repository
-	Forms *form.Repository
-	pool  *pgxpool.Pool
+	Users       *user.Repository
+	Forms       *form.Repository
+	Identities  *identity.Repository
+	AuthStates  *authstate.Repository
+	Tokens      *token.Repository
+	Sessions    *session.Repository
+	Audit       *audit.Repository
+	Idempotency *idempotency.Repository
+	RateLimits  *ratelimit.Repository
+	Departments *department.Repository
+	pool        *pgxpool.Pool
 }
 
-func NewRepository(ctx context.Context, connStr string) (*Repository, *manager.Manager, error) {
+func NewRepository(ctx context.Context, connStr string) (*Repository, trm.Manager, error) {
 	db, err := newPool(ctx, connStr)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	txMgr := manager.Must(trmpgx.NewDefaultFactory(db))
+	txMgr := tracing.NewTxManager(manager.Must(trmpgx.NewDefaultFactory(db)))
 
 	repo := &Repository{
-		Users: user.NewRepository(db),
-		Forms: form.NewRepository(db),
-		pool:  db,
+		Users:       user.NewRepository(db),
+		Forms:       form.NewRepository(db),
+		Identities:  identity.NewRepository(db),
+		AuthStates:  authstate.NewRepository(db),
+		Tokens:      token.NewRepository(db),
+		Sessions:    session.NewRepository(db),
+		Audit:       audit.NewRepository(db),
+		Idempotency: idempotency.NewRepository(db),
+		RateLimits:  ratelimit.NewRepository(db),
+		Departments: department.NewRepository(db),
+		pool:        db,
 	}
 
 	return repo, txMgr, nil
 }
 
 func newPool(ctx context.Context, connStr string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+	cfg, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+	cfg.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -52,6 +84,30 @@ func newPool(ctx context.Context, connStr string) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+// Ping checks that the database is reachable.
+func (r *Repository) Ping(ctx context.Context) error {
+	return r.pool.Ping(ctx)
+}
+
+// MigrationVersion returns the latest migration applied by goose.
+func (r *Repository) MigrationVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(MAX(version_id), 0)
+		FROM goose_db_version
+		WHERE is_applied`,
+	).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, nil
+}
+
+// Stat returns the statistics of the connection pool.
+func (r *Repository) Stat() *pgxpool.Stat {
+	return r.pool.Stat()
+}
+
 func (r *Repository) Close() {
 	if r.pool != nil {
 		r.pool.Close()
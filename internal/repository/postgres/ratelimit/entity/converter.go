@@ -0,0 +1,19 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToTokenBucketRecord(b domain.TokenBucket) TokenBucketRecord {
+	return TokenBucketRecord{
+		Key:       b.Key,
+		Tokens:    b.Tokens,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func ToDomainTokenBucket(br TokenBucketRecord) domain.TokenBucket {
+	return domain.TokenBucket{
+		Key:       br.Key,
+		Tokens:    br.Tokens,
+		UpdatedAt: br.UpdatedAt,
+	}
+}
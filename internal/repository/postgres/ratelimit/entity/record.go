@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type TokenBucketRecord struct {
+	Key       string    `db:"bucket_key"`
+	Tokens    float64   `db:"tokens"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/repository/postgres/ratelimit/entity"
+)
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+// Lock returns the bucket with the key of initial, creating it from initial if
+// it does not exist, and locks it until the end of the transaction.
+func (r *Repository) Lock(ctx context.Context, initial domain.TokenBucket) (*domain.TokenBucket, error) {
+	rec := entity.ToTokenBucketRecord(initial)
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	// The no-op update locks an existing row and returns it unchanged
+	query := `
+		INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (bucket_key) DO UPDATE SET bucket_key = EXCLUDED.bucket_key
+		RETURNING bucket_key, tokens, updated_at
+`
+	rows, err := conn.Query(ctx, query, rec.Key, rec.Tokens, rec.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.TokenBucketRecord])
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := entity.ToDomainTokenBucket(rec)
+	return &bucket, nil
+}
+
+func (r *Repository) Save(ctx context.Context, bucket *domain.TokenBucket) error {
+	rec := entity.ToTokenBucketRecord(*bucket)
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, `UPDATE rate_limit_buckets SET tokens = $1, updated_at = $2 WHERE bucket_key = $3`,
+		rec.Tokens, rec.UpdatedAt, rec.Key)
+	return err
+}
+
+// DeleteIdle removes buckets not used since before.
+func (r *Repository) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
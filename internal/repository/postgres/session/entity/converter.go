@@ -0,0 +1,43 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToSessionRecord(s domain.Session) SessionRecord {
+	return SessionRecord{
+		ID:         s.ID,
+		UserID:     s.UserID,
+		Device:     s.Device,
+		IPAddress:  s.IPAddress,
+		UserAgent:  s.UserAgent,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		RevokedAt:  s.RevokedAt,
+
+		ImpersonatorID: s.ImpersonatorID,
+	}
+}
+
+func ToDomainSession(sr SessionRecord) domain.Session {
+	return domain.Session{
+		ID:         sr.ID,
+		UserID:     sr.UserID,
+		Device:     sr.Device,
+		IPAddress:  sr.IPAddress,
+		UserAgent:  sr.UserAgent,
+		CreatedAt:  sr.CreatedAt,
+		LastSeenAt: sr.LastSeenAt,
+		ExpiresAt:  sr.ExpiresAt,
+		RevokedAt:  sr.RevokedAt,
+
+		ImpersonatorID: sr.ImpersonatorID,
+	}
+}
+
+func ToDomainSessions(records []SessionRecord) []domain.Session {
+	sessions := make([]domain.Session, len(records))
+	for i := range records {
+		sessions[i] = ToDomainSession(records[i])
+	}
+	return sessions
+}
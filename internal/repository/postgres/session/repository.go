@@ -0,0 +1,183 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/repository/postgres/session/entity"
+)
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, session *domain.Session) error {
+	rec := entity.ToSessionRecord(*session)
+	query := `
+		INSERT INTO sessions (id, user_id, device, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at, impersonator_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(
+		ctx,
+		query,
+		rec.ID,
+		rec.UserID,
+		rec.Device,
+		rec.IPAddress,
+		rec.UserAgent,
+		rec.CreatedAt,
+		rec.LastSeenAt,
+		rec.ExpiresAt,
+		rec.RevokedAt,
+		rec.ImpersonatorID,
+	)
+	return err
+}
+
+func (r *Repository) FindByID(ctx context.Context, sessionID uuid.UUID) (*domain.Session, error) {
+	query := `
+		SELECT id, user_id, device, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at, impersonator_id
+		FROM sessions
+		WHERE id = $1
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("query session: %w", err)
+	}
+
+	rec, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.SessionRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("collect session: %w", err)
+	}
+
+	session := entity.ToDomainSession(rec)
+	return &session, nil
+}
+
+func (r *Repository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	query := `
+		SELECT id, user_id, device, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at, impersonator_id
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now()
+		ORDER BY last_seen_at DESC
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query sessions: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.SessionRecord])
+	if err != nil {
+		return nil, fmt.Errorf("collect sessions: %w", err)
+	}
+
+	return entity.ToDomainSessions(records), nil
+}
+
+// Touch records the activity of a session. Only the activity columns are written,
+// so a revocation committed in the meantime is kept; such a session is reported as not found.
+func (r *Repository) Touch(ctx context.Context, session *domain.Session) error {
+	rec := entity.ToSessionRecord(*session)
+	query := `
+	UPDATE sessions
+	SET ip_address = $1, last_seen_at = $2
+	WHERE id = $3 AND revoked_at IS NULL`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, query, rec.IPAddress, rec.LastSeenAt, rec.ID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// Revoke revokes the session, an already revoked session keeps its revocation time.
+func (r *Repository) Revoke(ctx context.Context, sessionID uuid.UUID, revokedAt time.Time) error {
+	query := `
+	UPDATE sessions
+	SET revoked_at = $1
+	WHERE id = $2 AND revoked_at IS NULL`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, query, revokedAt, sessionID)
+	return err
+}
+
+// RevokeAllByUserID revokes every active session of the user and returns how many were revoked.
+func (r *Repository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int64, error) {
+	query := `
+	UPDATE sessions
+	SET revoked_at = $1
+	WHERE user_id = $2 AND revoked_at IS NULL AND expires_at > $1`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, query, revokedAt, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// RevokeOthersByUserID revokes every active session of the user except keepSessionID
+// and returns how many were revoked.
+func (r *Repository) RevokeOthersByUserID(ctx context.Context, userID, keepSessionID uuid.UUID, revokedAt time.Time) (int64, error) {
+	query := `
+	UPDATE sessions
+	SET revoked_at = $1
+	WHERE user_id = $2 AND id <> $3 AND revoked_at IS NULL AND expires_at > $1`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, query, revokedAt, userID, keepSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// DeleteByUserID deletes the sessions of the user, including those they opened
+// impersonating someone else, with their addresses and devices.
+func (r *Repository) DeleteByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM sessions WHERE user_id = $1 OR impersonator_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "github.com/platonso/hrmate/internal/domain"
+
+func ToTokenRecord(t domain.APIToken) TokenRecord {
+	scopes := make([]string, len(t.Scopes))
+	for i := range t.Scopes {
+		scopes[i] = string(t.Scopes[i])
+	}
+
+	return TokenRecord{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		TokenHash:  t.TokenHash,
+		Scopes:     scopes,
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+}
+
+func ToDomainToken(tr TokenRecord) domain.APIToken {
+	scopes := make([]domain.Scope, len(tr.Scopes))
+	for i := range tr.Scopes {
+		scopes[i] = domain.Scope(tr.Scopes[i])
+	}
+
+	return domain.APIToken{
+		ID:         tr.ID,
+		UserID:     tr.UserID,
+		Name:       tr.Name,
+		Prefix:     tr.Prefix,
+		TokenHash:  tr.TokenHash,
+		Scopes:     scopes,
+		CreatedAt:  tr.CreatedAt,
+		ExpiresAt:  tr.ExpiresAt,
+		LastUsedAt: tr.LastUsedAt,
+		RevokedAt:  tr.RevokedAt,
+	}
+}
+
+func ToDomainTokens(records []TokenRecord) []domain.APIToken {
+	tokens := make([]domain.APIToken, len(records))
+	for i := range records {
+		tokens[i] = ToDomainToken(records[i])
+	}
+	return tokens
+}
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/repository/postgres/token/entity"
+)
+
+type Repository struct {
+	db        *pgxpool.Pool
+	ctxGetter *trmpgx.CtxGetter
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db:        db,
+		ctxGetter: trmpgx.DefaultCtxGetter,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, token *domain.APIToken) error {
+	rec := entity.ToTokenRecord(*token)
+	query := `
+		INSERT INTO api_tokens (id, user_id, name, prefix, token_hash, scopes, created_at, expires_at, last_used_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(
+		ctx,
+		query,
+		rec.ID,
+		rec.UserID,
+		rec.Name,
+		rec.Prefix,
+		rec.TokenHash,
+		rec.Scopes,
+		rec.CreatedAt,
+		rec.ExpiresAt,
+		rec.LastUsedAt,
+		rec.RevokedAt,
+	)
+	return err
+}
+
+func (r *Repository) FindByID(ctx context.Context, tokenID uuid.UUID) (*domain.APIToken, error) {
+	query := `
+		SELECT id, user_id, name, prefix, token_hash, scopes, created_at, expires_at, last_used_at, revoked_at
+		FROM api_tokens
+		WHERE id = $1
+`
+	return r.findToken(ctx, query, tokenID)
+}
+
+func (r *Repository) FindByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	query := `
+		SELECT id, user_id, name, prefix, token_hash, scopes, created_at, expires_at, last_used_at, revoked_at
+		FROM api_tokens
+		WHERE token_hash = $1
+`
+	return r.findToken(ctx, query, tokenHash)
+}
+
+func (r *Repository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.APIToken, error) {
+	query := `
+		SELECT id, user_id, name, prefix, token_hash, scopes, created_at, expires_at, last_used_at, revoked_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query tokens: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.TokenRecord])
+	if err != nil {
+		return nil, fmt.Errorf("collect tokens: %w", err)
+	}
+
+	return entity.ToDomainTokens(records), nil
+}
+
+func (r *Repository) Update(ctx context.Context, token *domain.APIToken) error {
+	rec := entity.ToTokenRecord(*token)
+	query := `
+	UPDATE api_tokens
+	SET last_used_at = $1, revoked_at = $2
+	WHERE id = $3`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, query, rec.LastUsedAt, rec.RevokedAt, rec.ID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) TouchLastUsed(ctx context.Context, tokenID uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE api_tokens SET last_used_at = $1 WHERE id = $2`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, query, usedAt, tokenID)
+	return err
+}
+
+func (r *Repository) findToken(ctx context.Context, query string, args ...any) (*domain.APIToken, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query token: %w", err)
+	}
+
+	rec, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.TokenRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("collect token: %w", err)
+	}
+
+	token := entity.ToDomainToken(rec)
+	return &token, nil
+}
+
+func (r *Repository) DeleteByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM api_tokens WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -12,6 +12,17 @@ func ToUserRecord(u domain.User) UserRecord {
 		Email:          u.Email,
 		HashedPassword: u.HashedPassword,
 		IsActive:       u.IsActive,
+
+		IsServiceAccount: u.IsServiceAccount,
+		ManagerID:        u.ManagerID,
+		Version:          u.Version,
+	}
+
+	if u.Locale != "" {
+		record.Locale = &u.Locale
+	}
+	if u.Department != "" {
+		record.Department = &u.Department
 	}
 	return record
 }
@@ -26,6 +37,17 @@ func ToDomainUser(ur UserRecord) domain.User {
 		Email:          ur.Email,
 		HashedPassword: ur.HashedPassword,
 		IsActive:       ur.IsActive,
+
+		IsServiceAccount: ur.IsServiceAccount,
+		ManagerID:        ur.ManagerID,
+		Version:          ur.Version,
+	}
+
+	if ur.Locale != nil {
+		user.Locale = *ur.Locale
+	}
+	if ur.Department != nil {
+		user.Department = *ur.Department
 	}
 	return user
 }
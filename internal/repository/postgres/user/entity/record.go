@@ -13,4 +13,10 @@ type UserRecord struct {
 	Email          string    `db:"email"`
 	HashedPassword string    `db:"hashed_password"`
 	IsActive       bool      `db:"is_active"`
+
+	IsServiceAccount bool       `db:"is_service_account"`
+	Locale           *string    `db:"locale"`
+	Department       *string    `db:"department"`
+	ManagerID        *uuid.UUID `db:"manager_id"`
+	Version          int        `db:"version"`
 }
@@ -3,16 +3,26 @@ package user
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
+	"strings"
 
 	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/platonso/hrmate/internal/domain"
 	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
 	"github.com/platonso/hrmate/internal/repository/postgres/user/entity"
 	"github.com/platonso/hrmate/internal/service/assignment"
+	userservice "github.com/platonso/hrmate/internal/service/user"
+)
+
+// PostgreSQL error codes of the constraints the repository translates
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
 )
 
 type Repository struct {
@@ -30,8 +40,8 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 func (r *Repository) Create(ctx context.Context, user *domain.User) error {
 	rec := entity.ToUserRecord(*user)
 	query := `
-		INSERT INTO users (id, user_role, first_name, last_name, position, email, hashed_password, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 `
 	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
 
@@ -46,13 +56,21 @@ func (r *Repository) Create(ctx context.Context, user *domain.User) error {
 		rec.Email,
 		rec.HashedPassword,
 		rec.IsActive,
+		rec.IsServiceAccount,
+		rec.Locale,
+		rec.Department,
+		rec.ManagerID,
+		rec.Version,
 	)
+	if isViolation(err, uniqueViolation) {
+		return errs.ErrUserAlreadyExists
+	}
 	return err
 }
 
 func (r *Repository) FindByUserID(ctx context.Context, userId uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version
 		FROM users
 		WHERE id = $1		
 `
@@ -70,7 +88,7 @@ func (r *Repository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]
 	}
 
 	query := `
-		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version
 		FROM users
 		WHERE id = ANY($1)
 	`
@@ -79,13 +97,13 @@ func (r *Repository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]
 
 	rows, err := conn.Query(ctx, query, userIDs)
 	if err != nil {
-		log.Printf("query failed: %v", err)
+		logger.FromContext(ctx).Error("failed to query users by ids", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
 	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.UserRecord])
 	if err != nil {
-		log.Printf("collect rows: %v", err)
+		logger.FromContext(ctx).Error("failed to collect users", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
@@ -98,7 +116,7 @@ func (r *Repository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]
 
 func (r *Repository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version
 		FROM users
 		WHERE email = $1		
 `
@@ -110,6 +128,61 @@ func (r *Repository) FindByEmail(ctx context.Context, email string) (*domain.Use
 	return &user, nil
 }
 
+func (r *Repository) FindByEmails(ctx context.Context, emails []string) ([]domain.User, error) {
+	if len(emails) == 0 {
+		return []domain.User{}, nil
+	}
+
+	query := `
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version
+		FROM users
+		WHERE email = ANY($1)
+	`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, emails)
+	if err != nil {
+		return nil, fmt.Errorf("query users by emails: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.UserRecord])
+	if err != nil {
+		return nil, fmt.Errorf("collect users by emails: %w", err)
+	}
+
+	return entity.ToDomainUsers(records), nil
+}
+
+func (r *Repository) FindByDepartments(ctx context.Context, departments []string) ([]domain.User, error) {
+	if len(departments) == 0 {
+		return []domain.User{}, nil
+	}
+
+	query := `
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version
+		FROM users
+		WHERE department = ANY($1)
+		ORDER BY last_name, first_name, id
+	`
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query, departments)
+	if err != nil {
+		return nil, fmt.Errorf("query users by departments: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.UserRecord])
+	if err != nil {
+		return nil, fmt.Errorf("collect users by departments: %w", err)
+	}
+
+	return entity.ToDomainUsers(records), nil
+}
+
+// Update saves the user only if it has not been changed since it was read,
+// otherwise errs.ErrPreconditionFailed is returned. On success the version is incremented.
 func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 	rec := entity.ToUserRecord(*user)
 	query := `
@@ -120,8 +193,12 @@ func (r *Repository) Update(ctx context.Context, user *domain.User) error {
             position = $4,
             email = $5,
             hashed_password = $6,
-            is_active = $7
-        WHERE id = $8
+            is_active = $7,
+            locale = $8,
+            department = $9,
+            manager_id = $10,
+            version = version + 1
+        WHERE id = $11 AND version = $12
     `
 
 	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
@@ -134,27 +211,85 @@ func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 		rec.Email,
 		rec.HashedPassword,
 		rec.IsActive,
+		rec.Locale,
+		rec.Department,
+		rec.ManagerID,
 		rec.ID,
+		rec.Version,
 	)
 
 	if err != nil {
+		if isViolation(err, uniqueViolation) {
+			return errs.ErrUserAlreadyExists
+		}
 		return err
 	}
 
 	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := conn.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, rec.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return errs.ErrPreconditionFailed
+		}
 		return errs.ErrUserNotFound
 	}
 
+	user.Version++
 	return nil
 }
 
+// Delete removes the user with their sessions, tokens and identities. Users referenced
+// by forms are kept, errs.ErrUserHasForms is returned for them.
+func (r *Repository) Delete(ctx context.Context, userID uuid.UUID) error {
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return errs.ErrUserHasForms
+		}
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrUserNotFound
+	}
+	return nil
+}
+
+// LockActiveAdmins returns the administrators able to sign in and locks them until the
+// transaction ends, so concurrent demotions cannot remove the last one together.
+func (r *Repository) LockActiveAdmins(ctx context.Context) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE user_role = 'admin' AND is_active = true AND is_service_account = false
+		FOR UPDATE
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query active admins: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("collect active admins: %w", err)
+	}
+
+	return ids, nil
+}
+
 func (r *Repository) FindByRole(ctx context.Context, roles ...domain.Role) ([]domain.User, error) {
 	if len(roles) == 0 {
 		return []domain.User{}, nil
 	}
 
 	query := `
-		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version
 		FROM users
 		WHERE user_role = ANY($1)
 `
@@ -162,13 +297,13 @@ func (r *Repository) FindByRole(ctx context.Context, roles ...domain.Role) ([]do
 
 	rows, err := conn.Query(ctx, query, roles)
 	if err != nil {
-		log.Printf("failed to query users by roles %v: %v", roles, err)
+		logger.FromContext(ctx).Error("failed to query users by roles", "roles", roles, "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
 	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.UserRecord])
 	if err != nil {
-		log.Printf("collect rows: %v", err)
+		logger.FromContext(ctx).Error("failed to collect users", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
@@ -180,6 +315,28 @@ func (r *Repository) FindByRole(ctx context.Context, roles ...domain.Role) ([]do
 	return users, nil
 }
 
+func (r *Repository) FindServiceAccounts(ctx context.Context) ([]domain.User, error) {
+	query := `
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version
+		FROM users
+		WHERE is_service_account = true
+		ORDER BY first_name
+`
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query service accounts: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.UserRecord])
+	if err != nil {
+		return nil, fmt.Errorf("collect service accounts: %w", err)
+	}
+
+	return entity.ToDomainUsers(records), nil
+}
+
 func (r *Repository) IsActive(ctx context.Context, userID uuid.UUID) (bool, error) {
 	query := `SELECT is_active FROM users WHERE id = $1`
 
@@ -212,6 +369,11 @@ func (r *Repository) findUser(ctx context.Context, query string, args ...any) (e
 		&rec.Email,
 		&rec.HashedPassword,
 		&rec.IsActive,
+		&rec.IsServiceAccount,
+		&rec.Locale,
+		&rec.Department,
+		&rec.ManagerID,
+		&rec.Version,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -223,6 +385,125 @@ func (r *Repository) findUser(ctx context.Context, query string, args ...any) (e
 	return rec, nil
 }
 
+// sortKeys are the expressions the user lists are ordered by, matching the sort indexes
+var sortKeys = map[userservice.SortField]string{
+	userservice.SortByName:     "lower(last_name || ' ' || first_name)",
+	userservice.SortByEmail:    "email",
+	userservice.SortByPosition: "lower(position)",
+	userservice.SortByRole:     "user_role",
+}
+
+type pageRecord struct {
+	entity.UserRecord
+	SortKey string `db:"sort_key"`
+}
+
+// FindPage returns the users matching the filter in keyset order: the page starts
+// behind the cursor of the filter and ends with the cursor of the next page, if any.
+func (r *Repository) FindPage(ctx context.Context, filter *userservice.Filter) (*userservice.Page, error) {
+	sortKey, ok := sortKeys[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field: %s", filter.Sort)
+	}
+
+	conditions := []string{"user_role = ANY($1)"}
+	args := []any{filter.Roles}
+	argPos := 2
+
+	if filter.Role != nil {
+		conditions = append(conditions, fmt.Sprintf("user_role = $%d", argPos))
+		args = append(args, string(*filter.Role))
+		argPos++
+	}
+
+	if filter.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", argPos))
+		args = append(args, *filter.IsActive)
+		argPos++
+	}
+
+	if filter.Department != nil {
+		conditions = append(conditions, fmt.Sprintf("department = $%d", argPos))
+		args = append(args, *filter.Department)
+		argPos++
+	}
+
+	if filter.Email != nil {
+		conditions = append(conditions, fmt.Sprintf("lower(email) = lower($%d)", argPos))
+		args = append(args, *filter.Email)
+		argPos++
+	}
+
+	if filter.ExcludeServiceAccounts {
+		conditions = append(conditions, "NOT is_service_account")
+	}
+
+	if filter.Search != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"((first_name || ' ' || last_name) ILIKE $%[1]d OR email ILIKE $%[1]d OR position ILIKE $%[1]d)", argPos))
+		args = append(args, "%"+likeEscaper.Replace(filter.Search)+"%")
+		argPos++
+	}
+
+	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
+
+	// The total ignores the position in the listing
+	var total int
+	if filter.WithTotal {
+		query := "SELECT count(*) FROM users WHERE " + strings.Join(conditions, " AND ")
+		if err := conn.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("count users: %w", err)
+		}
+	}
+
+	order, comparison := "ASC", ">"
+	if filter.Desc {
+		order, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d, $%d)", sortKey, comparison, argPos, argPos+1))
+		args = append(args, filter.After.Key, filter.After.ID)
+		argPos += 2
+	}
+
+	// One more row than requested tells whether there is a next page
+	query := fmt.Sprintf(`
+		SELECT id, user_role, first_name, last_name, position, email, hashed_password, is_active, is_service_account, locale, department, manager_id, version,
+			%[1]s AS sort_key
+		FROM users
+		WHERE %[2]s
+		ORDER BY %[1]s %[3]s, id %[3]s
+		LIMIT $%[4]d OFFSET $%[5]d
+`, sortKey, strings.Join(conditions, " AND "), order, argPos, argPos+1)
+	args = append(args, filter.Limit+1, filter.Offset)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query users page: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[pageRecord])
+	if err != nil {
+		return nil, fmt.Errorf("collect users page: %w", err)
+	}
+
+	page := &userservice.Page{Users: make([]domain.User, 0, min(len(records), filter.Limit)), Total: total}
+	if len(records) > filter.Limit {
+		records = records[:filter.Limit]
+		last := records[len(records)-1]
+		page.Next = &userservice.Cursor{Sort: filter.Sort, Desc: filter.Desc, Key: last.SortKey, ID: last.ID}
+	}
+	for _, rec := range records {
+		page.Users = append(page.Users, entity.ToDomainUser(rec.UserRecord))
+	}
+
+	return page, nil
+}
+
+// likeEscaper makes the wildcards of a search term match literally in ILIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func (r *Repository) FindActiveHRsWithWorkload(ctx context.Context) ([]assignment.HRWorkload, error) {
 	query := `
 				SELECT 
@@ -230,16 +511,16 @@ func (r *Repository) FindActiveHRsWithWorkload(ctx context.Context) ([]assignmen
 					COALESCE(COUNT(f.id) FILTER (WHERE f.status = 'pending'), 0) AS pending_forms_count
 				FROM users u
 				LEFT JOIN forms f ON f.executor_id = u.id
-				WHERE u.user_role = 'hr' AND u.is_active = true
+				WHERE u.user_role = 'hr' AND u.is_active = true AND u.is_service_account = false
 				GROUP BY u.id
 				ORDER BY pending_forms_count , u.id
 			`
 
 	conn := r.ctxGetter.DefaultTrOrDB(ctx, r.db)
-	
+
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		log.Printf("failed to query active HRs with workload: %v", err)
+		logger.FromContext(ctx).Error("failed to query active HRs with workload", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 	defer rows.Close()
@@ -248,16 +529,21 @@ func (r *Repository) FindActiveHRsWithWorkload(ctx context.Context) ([]assignmen
 	for rows.Next() {
 		var hw assignment.HRWorkload
 		if err := rows.Scan(&hw.UserID, &hw.PendingFormsCount); err != nil {
-			log.Printf("failed to scan HR workload: %v", err)
+			logger.FromContext(ctx).Error("failed to scan HR workload", "error", err)
 			return nil, errs.ErrInternalServer
 		}
 		results = append(results, hw)
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("rows iteration error: %v", err)
+		logger.FromContext(ctx).Error("failed to iterate HR workload rows", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
 	return results, nil
 }
+
+func isViolation(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
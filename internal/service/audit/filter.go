@@ -0,0 +1,11 @@
+package audit
+
+import "github.com/google/uuid"
+
+type Filter struct {
+	ActorID   *uuid.UUID
+	SubjectID *uuid.UUID
+	// InvolvedUserID matches entries where the user is the actor, the subject or the resource
+	InvolvedUserID *uuid.UUID
+	Limit          int
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type Repository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	FindByFilter(ctx context.Context, filter *Filter) ([]domain.AuditEntry, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	ctx, span := tracing.Start(ctx, "audit.Service.Record")
+	defer span.End()
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		logger.FromContext(ctx).Error("failed to record audit entry", "method", entry.Method, "path", entry.Path, "actor_id", entry.ActorID, "error", err)
+		return errs.ErrInternalServer
+	}
+	return nil
+}
+
+func (s *Service) GetEntries(ctx context.Context, filter *Filter) ([]domain.AuditEntry, error) {
+	ctx, span := tracing.Start(ctx, "audit.Service.GetEntries")
+	defer span.End()
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultLimit
+	case filter.Limit > maxLimit:
+		filter.Limit = maxLimit
+	}
+
+	entries, err := s.repo.FindByFilter(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find audit entries", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	return entries, nil
+}
@@ -1,6 +1,11 @@
 package model
 
-import "github.com/platonso/hrmate/internal/domain"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+)
 
 type RegisterInput struct {
 	FirstName string
@@ -10,3 +15,18 @@ type RegisterInput struct {
 	Password  string
 	Role      domain.Role
 }
+
+// AccessClaims is the identity carried by a valid access token.
+type AccessClaims struct {
+	UserID    uuid.UUID
+	Role      domain.Role
+	SessionID uuid.UUID
+	// ActorID is the administrator behind an impersonation session
+	ActorID *uuid.UUID
+}
+
+type ImpersonationResult struct {
+	Token     string
+	SubjectID uuid.UUID
+	ExpiresAt time.Time
+}
@@ -3,46 +3,86 @@ package auth
 import (
 	"context"
 	"errors"
-	"log"
+	"time"
 
-	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/platonso/hrmate/internal/domain"
 	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
 	"github.com/platonso/hrmate/internal/service/auth/model"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/platonso/hrmate/internal/tracing"
 )
 
 type Repository interface {
 	Create(ctx context.Context, user *domain.User) error
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 	FindByRole(ctx context.Context, roles ...domain.Role) ([]domain.User, error)
+	FindByUserID(ctx context.Context, userId uuid.UUID) (*domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
 }
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int64, error)
+	RevokeOthersByUserID(ctx context.Context, userID, keepSessionID uuid.UUID, revokedAt time.Time) (int64, error)
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (match bool, needsRehash bool, err error)
+}
+
+type PasswordPolicy interface {
+	Validate(password, email string) error
+}
+
 type Service struct {
-	txMgr     *manager.Manager
-	repo      Repository
-	jwtSecret string
+	txMgr            trm.Manager
+	repo             Repository
+	sessionRepo      SessionRepository
+	hasher           PasswordHasher
+	policy           PasswordPolicy
+	jwtSecret        string
+	sessionTTL       time.Duration
+	impersonationTTL time.Duration
 }
 
-func NewService(txMgr *manager.Manager, repo Repository, jwtSecret string) *Service {
+func NewService(
+	txMgr trm.Manager,
+	repo Repository,
+	sessionRepo SessionRepository,
+	hasher PasswordHasher,
+	policy PasswordPolicy,
+	jwtSecret string,
+	sessionTTL time.Duration,
+	impersonationTTL time.Duration,
+) *Service {
 	return &Service{
-		txMgr:     txMgr,
-		repo:      repo,
-		jwtSecret: jwtSecret,
+		txMgr:            txMgr,
+		repo:             repo,
+		sessionRepo:      sessionRepo,
+		hasher:           hasher,
+		policy:           policy,
+		jwtSecret:        jwtSecret,
+		sessionTTL:       sessionTTL,
+		impersonationTTL: impersonationTTL,
 	}
 }
 
 func (s *Service) ImplementAdmin(ctx context.Context, email, password string) error {
+	ctx, span := tracing.Start(ctx, "auth.Service.ImplementAdmin")
+	defer span.End()
 
 	admin, err := s.repo.FindByRole(ctx, domain.RoleAdmin)
 	if err != nil {
-		log.Printf("failed to find admin: %v", err)
+		logger.FromContext(ctx).Error("failed to find admin", "error", err)
 		return errs.ErrInternalServer
 	}
 
 	if len(admin) > 0 {
-		log.Println("the existing admin is used")
+		logger.FromContext(ctx).Info("the existing admin is used")
 		return nil
 	}
 
@@ -50,9 +90,14 @@ func (s *Service) ImplementAdmin(ctx context.Context, email, password string) er
 		return errs.ErrInvalidRequest
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	// The admin password comes from the environment, so a weak one must not block startup
+	if err := s.policy.Validate(password, email); err != nil {
+		logger.FromContext(ctx).Warn("admin password does not satisfy the password policy", "error", err)
+	}
+
+	hashedPassword, err := s.hasher.Hash(password)
 	if err != nil {
-		log.Printf("failed to hash admin password: %v", err)
+		logger.FromContext(ctx).Error("failed to hash admin password", "error", err)
 		return errs.ErrInternalServer
 	}
 
@@ -62,36 +107,170 @@ func (s *Service) ImplementAdmin(ctx context.Context, email, password string) er
 		"user",
 		"Administrator",
 		email,
-		string(hashedPassword),
+		hashedPassword,
 	)
 
 	adminUser.Activate()
 
 	if err := s.repo.Create(ctx, &adminUser); err != nil {
-		log.Printf("failed to create admin: %v", err)
+		logger.FromContext(ctx).Error("failed to create admin", "error", err)
+		return errs.ErrInternalServer
+	}
+
+	logger.FromContext(ctx).Info("admin has been created successfully")
+	return nil
+}
+
+// ResetPassword sets a new password for the user and signs them out everywhere.
+// It is meant for operators locked out of the API, e.g. when the admin password is lost.
+func (s *Service) ResetPassword(ctx context.Context, email, password string) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "auth.Service.ResetPassword")
+	defer span.End()
+
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find user by email", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	if user.IsServiceAccount {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.policy.Validate(password, user.Email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to hash password", "target_user_id", user.ID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	if err := s.setPassword(ctx, user, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sessionRepo.RevokeAllByUserID(ctx, user.ID, time.Now()); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke sessions", "target_user_id", user.ID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	return user, nil
+}
+
+// ChangePassword replaces the password of the user, e.g. the temporary one of an
+// invitation, and signs out every session but the current one.
+func (s *Service) ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, currentPassword, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "auth.Service.ChangePassword")
+	defer span.End()
+
+	user, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return errs.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find user", "target_user_id", userID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	// Accounts provisioned through SSO and service accounts have no password to change
+	if user.HashedPassword == "" {
+		return errs.ErrInvalidCredentials
+	}
+
+	match, _, err := s.hasher.Verify(currentPassword, user.HashedPassword)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to verify password", "target_user_id", user.ID, "error", err)
+		return errs.ErrInvalidCredentials
+	}
+	if !match {
+		return errs.ErrInvalidCredentials
+	}
+
+	if err := s.policy.Validate(newPassword, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to hash password", "target_user_id", user.ID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	if err := s.setPassword(ctx, user, hashedPassword); err != nil {
+		return err
+	}
+
+	if _, err := s.sessionRepo.RevokeOthersByUserID(ctx, user.ID, sessionID, time.Now()); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke sessions", "target_user_id", user.ID, "error", err)
 		return errs.ErrInternalServer
 	}
 
-	log.Println("admin has been created successfully")
 	return nil
 }
 
-func (s *Service) Register(ctx context.Context, registerInput *model.RegisterInput) (string, error) {
+// setPassword stores the new password hash of the user. A concurrent edit of the
+// profile changes the version, so the user is read again and the update retried
+// once; a password changed in the meantime is not overwritten (errs.ErrPreconditionFailed).
+func (s *Service) setPassword(ctx context.Context, user *domain.User, hashedPassword string) error {
+	previousHash := user.HashedPassword
+
+	for attempt := 0; ; attempt++ {
+		user.HashedPassword = hashedPassword
+		err := s.repo.Update(ctx, user)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, errs.ErrPreconditionFailed) {
+			logger.FromContext(ctx).Error("failed to update user", "target_user_id", user.ID, "error", err)
+			return errs.ErrInternalServer
+		}
+		if attempt > 0 {
+			return errs.ErrPreconditionFailed
+		}
+
+		current, err := s.repo.FindByUserID(ctx, user.ID)
+		if err != nil {
+			if errors.Is(err, errs.ErrUserNotFound) {
+				return errs.ErrUserNotFound
+			}
+			logger.FromContext(ctx).Error("failed to find user", "target_user_id", user.ID, "error", err)
+			return errs.ErrInternalServer
+		}
+		if current.HashedPassword != previousHash {
+			return errs.ErrPreconditionFailed
+		}
+		*user = *current
+	}
+}
+
+func (s *Service) Register(ctx context.Context, registerInput *model.RegisterInput, client domain.ClientInfo) (string, error) {
+	ctx, span := tracing.Start(ctx, "auth.Service.Register")
+	defer span.End()
+
+	if err := s.policy.Validate(registerInput.Password, registerInput.Email); err != nil {
+		return "", err
+	}
+
 	var user domain.User
 
 	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
 		existingUser, err := s.repo.FindByEmail(txCtx, registerInput.Email)
 		if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
-			log.Printf("failed to check user existence: %v", err)
+			logger.FromContext(ctx).Error("failed to check user existence", "error", err)
 			return errs.ErrInternalServer
 		}
 
 		if existingUser != nil {
 			return errs.ErrUserAlreadyExists
 		}
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(registerInput.Password), bcrypt.DefaultCost)
+		hashedPassword, err := s.hasher.Hash(registerInput.Password)
 		if err != nil {
-			log.Printf("failed to hash password: %v", err)
+			logger.FromContext(ctx).Error("failed to hash password", "error", err)
 			return errs.ErrInternalServer
 		}
 
@@ -101,11 +280,11 @@ func (s *Service) Register(ctx context.Context, registerInput *model.RegisterInp
 			registerInput.LastName,
 			registerInput.Position,
 			registerInput.Email,
-			string(hashedPassword),
+			hashedPassword,
 		)
 
 		if err := s.repo.Create(txCtx, &user); err != nil {
-			log.Printf("failed to create user: %v", err)
+			logger.FromContext(ctx).Error("failed to create user", "error", err)
 			return errs.ErrInternalServer
 		}
 		return nil
@@ -113,26 +292,33 @@ func (s *Service) Register(ctx context.Context, registerInput *model.RegisterInp
 		return "", err
 	}
 
-	token, err := generateJWT(user.ID, user.Role, s.jwtSecret)
-	if err != nil {
-		log.Printf("failed to generate JWT: %v", err)
-		return "", errs.ErrInternalServer
-	}
-
-	return token, nil
+	return s.IssueToken(ctx, &user, client)
 }
 
-func (s *Service) Login(ctx context.Context, email, password string) (string, error) {
+func (s *Service) Login(ctx context.Context, email, password string, client domain.ClientInfo) (string, error) {
+	ctx, span := tracing.Start(ctx, "auth.Service.Login")
+	defer span.End()
+
 	user, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
 			return "", errs.ErrInvalidCredentials
 		}
-		log.Printf("failed to find user by email: %v", err)
+		logger.FromContext(ctx).Error("failed to find user by email", "error", err)
 		return "", errs.ErrInternalServer
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(password)); err != nil {
+	// Accounts provisioned through SSO and service accounts have no password hash
+	if user.HashedPassword == "" {
+		return "", errs.ErrInvalidCredentials
+	}
+
+	match, needsRehash, err := s.hasher.Verify(password, user.HashedPassword)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to verify password", "target_user_id", user.ID, "error", err)
+		return "", errs.ErrInvalidCredentials
+	}
+	if !match {
 		return "", errs.ErrInvalidCredentials
 	}
 
@@ -140,24 +326,163 @@ func (s *Service) Login(ctx context.Context, email, password string) (string, er
 		return "", errs.ErrUserNotActive
 	}
 
-	token, err := generateJWT(user.ID, user.Role, s.jwtSecret)
+	if needsRehash {
+		s.rehash(ctx, user, password)
+	}
+
+	return s.IssueToken(ctx, user, client)
+}
+
+// rehash upgrades a legacy or outdated password hash. Failures are not fatal:
+// the old hash stays valid and the upgrade is retried on the next login.
+func (s *Service) rehash(ctx context.Context, user *domain.User, password string) {
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to rehash password", "target_user_id", user.ID, "error", err)
+		return
+	}
+
+	user.HashedPassword = hashedPassword
+	if err := s.repo.Update(ctx, user); err != nil {
+		logger.FromContext(ctx).Warn("failed to store rehashed password", "target_user_id", user.ID, "error", err)
+	}
+}
+
+// IssueToken starts a new session for an already authenticated user and returns its access token.
+func (s *Service) IssueToken(ctx context.Context, user *domain.User, client domain.ClientInfo) (string, error) {
+	ctx, span := tracing.Start(ctx, "auth.Service.IssueToken")
+	defer span.End()
+
+	session := domain.NewSession(user.ID, client, s.sessionTTL)
+	if err := s.sessionRepo.Create(ctx, &session); err != nil {
+		logger.FromContext(ctx).Error("failed to create session", "target_user_id", user.ID, "error", err)
+		return "", errs.ErrInternalServer
+	}
+
+	token, err := generateJWT(&session, user.Role, s.jwtSecret)
 	if err != nil {
-		log.Printf("failed to generate JWT: %v", err)
+		logger.FromContext(ctx).Error("failed to generate JWT", "error", err)
 		return "", errs.ErrInternalServer
 	}
 
 	return token, nil
 }
 
-func (s *Service) GetJWTSecret() string {
-	return s.jwtSecret
+// Impersonate issues a short-lived token that lets an admin see the API as the subject user.
+func (s *Service) Impersonate(ctx context.Context, actorID, subjectID uuid.UUID, client domain.ClientInfo) (*model.ImpersonationResult, error) {
+	ctx, span := tracing.Start(ctx, "auth.Service.Impersonate")
+	defer span.End()
+
+	if actorID == subjectID {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	subject, err := s.repo.FindByUserID(ctx, subjectID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find user", "target_user_id", subjectID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	// Admins and integrations are not impersonated: it would only hide who acted
+	if subject.Role == domain.RoleAdmin || subject.IsServiceAccount {
+		return nil, errs.ErrForbidden
+	}
+
+	if !subject.IsActive {
+		return nil, errs.ErrUserNotActive
+	}
+
+	session := domain.NewImpersonationSession(actorID, subject.ID, client, s.impersonationTTL)
+	if err := s.sessionRepo.Create(ctx, &session); err != nil {
+		logger.FromContext(ctx).Error("failed to create impersonation session", "target_user_id", subject.ID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	token, err := generateJWT(&session, subject.Role, s.jwtSecret)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate JWT", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	logger.FromContext(ctx).Info("impersonation started", "actor_id", actorID, "target_user_id", subject.ID)
+
+	return &model.ImpersonationResult{
+		Token:     token,
+		SubjectID: subject.ID,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// ParseAccessToken verifies the signature and expiry of a JWT issued by IssueToken
+// and returns its claims. Whether the session is still active is not checked here.
+func (s *Service) ParseAccessToken(tokenString string) (*model.AccessClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(s.jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errs.ErrUnauthorized
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errs.ErrUnauthorized
+	}
+
+	userIDStr, ok1 := claims["id"].(string)
+	roleStr, ok2 := claims["role"].(string)
+	sessionIDStr, ok3 := claims["sid"].(string)
+	if !ok1 || !ok2 || !ok3 {
+		return nil, errs.ErrUnauthorized
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, errs.ErrUnauthorized
+	}
+
+	sessionID, err := uuid.Parse(sessionIDStr)
+	if err != nil {
+		return nil, errs.ErrUnauthorized
+	}
+
+	result := &model.AccessClaims{
+		UserID:    userID,
+		Role:      domain.Role(roleStr),
+		SessionID: sessionID,
+	}
+
+	if act, ok := claims["act"].(map[string]any); ok {
+		actorIDStr, _ := act["sub"].(string)
+		actorID, err := uuid.Parse(actorIDStr)
+		if err != nil {
+			return nil, errs.ErrUnauthorized
+		}
+		result.ActorID = &actorID
+	}
+
+	return result, nil
 }
 
-func generateJWT(userID uuid.UUID, role domain.Role, secret string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":   userID,
+func generateJWT(session *domain.Session, role domain.Role, secret string) (string, error) {
+	claims := jwt.MapClaims{
+		"id":   session.UserID,
 		"role": role,
-	})
+		"sid":  session.ID,
+		"exp":  session.ExpiresAt.Unix(),
+	}
+
+	// Actor claim as defined in RFC 8693
+	if session.ImpersonatorID != nil {
+		claims["act"] = map[string]any{"sub": *session.ImpersonatorID}
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	tokenString, err := token.SignedString([]byte(secret))
 	if err != nil {
@@ -0,0 +1,9 @@
+package model
+
+import "github.com/platonso/hrmate/internal/domain"
+
+// DepartmentWithMembers is a department with the users assigned to it, sorted by name.
+type DepartmentWithMembers struct {
+	Department domain.Department
+	Members    []domain.User
+}
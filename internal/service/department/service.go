@@ -0,0 +1,292 @@
+package department
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/service/department/model"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+// maxNameLength matches the limit on the department of a user
+const maxNameLength = 100
+
+type Repository interface {
+	Create(ctx context.Context, department *domain.Department) error
+	Update(ctx context.Context, department *domain.Department) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindAll(ctx context.Context) ([]domain.Department, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Department, error)
+}
+
+type UserRepository interface {
+	Update(ctx context.Context, user *domain.User) error
+	FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.User, error)
+	FindByDepartments(ctx context.Context, departments []string) ([]domain.User, error)
+}
+
+// Service manages departments and their members. A user belongs to at most one
+// department, so adding them to a department moves them out of their previous one.
+type Service struct {
+	txMgr trm.Manager
+	repo  Repository
+	users UserRepository
+}
+
+func NewService(txMgr trm.Manager, repo Repository, users UserRepository) *Service {
+	return &Service{
+		txMgr: txMgr,
+		repo:  repo,
+		users: users,
+	}
+}
+
+func (s *Service) GetDepartments(ctx context.Context) ([]model.DepartmentWithMembers, error) {
+	ctx, span := tracing.Start(ctx, "department.Service.GetDepartments")
+	defer span.End()
+
+	departments, err := s.repo.FindAll(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find departments", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	names := make([]string, len(departments))
+	for i, d := range departments {
+		names[i] = d.Name
+	}
+
+	users, err := s.users.FindByDepartments(ctx, names)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find department members", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	members := make(map[string][]domain.User, len(departments))
+	for _, u := range users {
+		members[u.Department] = append(members[u.Department], u)
+	}
+
+	result := make([]model.DepartmentWithMembers, len(departments))
+	for i, d := range departments {
+		result[i] = model.DepartmentWithMembers{Department: d, Members: members[d.Name]}
+	}
+	return result, nil
+}
+
+func (s *Service) GetDepartment(ctx context.Context, id uuid.UUID) (*model.DepartmentWithMembers, error) {
+	ctx, span := tracing.Start(ctx, "department.Service.GetDepartment")
+	defer span.End()
+
+	result, err := s.withMembers(ctx, id)
+	if err != nil {
+		return nil, s.failure(ctx, "failed to get department", id, err)
+	}
+	return result, nil
+}
+
+// CreateDepartment creates a department with the given members.
+func (s *Service) CreateDepartment(ctx context.Context, name string, memberIDs []uuid.UUID) (*model.DepartmentWithMembers, error) {
+	ctx, span := tracing.Start(ctx, "department.Service.CreateDepartment")
+	defer span.End()
+
+	name, err := validName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	department := domain.NewDepartment(name)
+
+	var result *model.DepartmentWithMembers
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Create(txCtx, &department); err != nil {
+			return err
+		}
+		if err := s.setMembers(txCtx, name, memberIDs); err != nil {
+			return err
+		}
+
+		result, err = s.withMembers(txCtx, department.ID)
+		return err
+	}); err != nil {
+		return nil, s.failure(ctx, "failed to create department", department.ID, err)
+	}
+
+	return result, nil
+}
+
+// ReplaceDepartment renames the department and makes the given users its only members.
+func (s *Service) ReplaceDepartment(ctx context.Context, id uuid.UUID, name string, memberIDs []uuid.UUID) (*model.DepartmentWithMembers, error) {
+	ctx, span := tracing.Start(ctx, "department.Service.ReplaceDepartment")
+	defer span.End()
+
+	name, err := validName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *model.DepartmentWithMembers
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		department, err := s.repo.FindByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		oldName := department.Name
+		if department.Rename(name) {
+			// The department is renamed first, so the users do not recreate the old name
+			if err := s.repo.Update(txCtx, department); err != nil {
+				return err
+			}
+			if err := s.moveMembers(txCtx, oldName, name); err != nil {
+				return err
+			}
+		}
+
+		if err := s.setMembers(txCtx, name, memberIDs); err != nil {
+			return err
+		}
+
+		result, err = s.withMembers(txCtx, id)
+		return err
+	}); err != nil {
+		return nil, s.failure(ctx, "failed to replace department", id, err)
+	}
+
+	return result, nil
+}
+
+// DeleteDepartment deletes the department and leaves its members without one.
+func (s *Service) DeleteDepartment(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "department.Service.DeleteDepartment")
+	defer span.End()
+
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		department, err := s.repo.FindByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.moveMembers(txCtx, department.Name, ""); err != nil {
+			return err
+		}
+		return s.repo.Delete(txCtx, id)
+	}); err != nil {
+		return s.failure(ctx, "failed to delete department", id, err)
+	}
+
+	return nil
+}
+
+func (s *Service) withMembers(ctx context.Context, id uuid.UUID) (*model.DepartmentWithMembers, error) {
+	department, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.users.FindByDepartments(ctx, []string{department.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DepartmentWithMembers{Department: *department, Members: members}, nil
+}
+
+// setMembers makes the users the only members of the department.
+func (s *Service) setMembers(ctx context.Context, name string, memberIDs []uuid.UUID) error {
+	current, err := s.users.FindByDepartments(ctx, []string{name})
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[uuid.UUID]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		wanted[id] = true
+	}
+
+	for i := range current {
+		if wanted[current[i].ID] {
+			delete(wanted, current[i].ID)
+			continue
+		}
+		current[i].ChangeDepartment("")
+		if err := s.users.Update(ctx, &current[i]); err != nil {
+			return err
+		}
+	}
+
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	added := make([]uuid.UUID, 0, len(wanted))
+	for id := range wanted {
+		added = append(added, id)
+	}
+
+	users, err := s.users.FindByUserIDs(ctx, added)
+	if err != nil {
+		return err
+	}
+	if len(users) != len(added) {
+		return errs.ErrUserNotFound
+	}
+
+	for i := range users {
+		if users[i].IsServiceAccount {
+			return errs.ErrInvalidRequest
+		}
+		users[i].ChangeDepartment(name)
+		if err := s.users.Update(ctx, &users[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveMembers moves every member of a department to another one, or out of it with an empty name.
+func (s *Service) moveMembers(ctx context.Context, from, to string) error {
+	members, err := s.users.FindByDepartments(ctx, []string{from})
+	if err != nil {
+		return err
+	}
+
+	for i := range members {
+		members[i].ChangeDepartment(to)
+		if err := s.users.Update(ctx, &members[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failure passes the errors the caller can act on and logs the others.
+func (s *Service) failure(ctx context.Context, msg string, id uuid.UUID, err error) error {
+	for _, known := range []error{
+		errs.ErrDepartmentNotFound,
+		errs.ErrDepartmentAlreadyExists,
+		errs.ErrUserNotFound,
+		errs.ErrInvalidRequest,
+		errs.ErrPreconditionFailed,
+	} {
+		if errors.Is(err, known) {
+			return known
+		}
+	}
+
+	logger.FromContext(ctx).Error(msg, "department_id", id, "error", err)
+	return errs.ErrInternalServer
+}
+
+func validName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxNameLength {
+		return "", errs.ErrInvalidRequest
+	}
+	return name, nil
+}
@@ -7,7 +7,9 @@ import (
 )
 
 type Filter struct {
-	UserID     *uuid.UUID
+	UserID *uuid.UUID
+	// UserIDs narrows the result to the forms of any of the users, e.g. to batch lookups
+	UserIDs    []uuid.UUID
 	ExecutorID *uuid.UUID
 	FormStatus *domain.FormStatus
 }
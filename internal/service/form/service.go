@@ -3,14 +3,16 @@ package form
 import (
 	"context"
 	"errors"
-	"log"
+	"slices"
 
-	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/google/uuid"
 	"github.com/platonso/hrmate/internal/domain"
 	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
 	"github.com/platonso/hrmate/internal/service/assignment"
 	"github.com/platonso/hrmate/internal/service/form/model"
+	"github.com/platonso/hrmate/internal/tracing"
 )
 
 type Repository interface {
@@ -28,26 +30,43 @@ type UserRepository interface {
 	FindActiveHRsWithWorkload(ctx context.Context) ([]assignment.HRWorkload, error)
 }
 
+type Notifier interface {
+	FormAssigned(ctx context.Context, form *domain.Form)
+	FormReviewed(ctx context.Context, form *domain.Form)
+}
+
+type Metrics interface {
+	FormCreated()
+	FormReviewed(form *domain.Form)
+}
+
 type Service struct {
-	txMgr    *manager.Manager
+	txMgr    trm.Manager
 	formRepo Repository
 	userRepo UserRepository
+	notifier Notifier
+	metrics  Metrics
 }
 
-func NewService(txMgr *manager.Manager, formRepo Repository, userRepo UserRepository) *Service {
+func NewService(txMgr trm.Manager, formRepo Repository, userRepo UserRepository, notifier Notifier, metrics Metrics) *Service {
 	return &Service{
 		txMgr:    txMgr,
 		formRepo: formRepo,
 		userRepo: userRepo,
+		notifier: notifier,
+		metrics:  metrics,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, formInput *model.FormCreateInput, userID uuid.UUID) (*domain.Form, error) {
+	ctx, span := tracing.Start(ctx, "form.Service.Create")
+	defer span.End()
+
 	var resultForm *domain.Form
 	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
 		hrs, err := s.userRepo.FindActiveHRsWithWorkload(txCtx)
 		if err != nil {
-			log.Printf("failed to find active HRs: %v", err)
+			logger.FromContext(ctx).Error("failed to find active HRs", "error", err)
 			return errs.ErrInternalServer
 		}
 
@@ -56,7 +75,7 @@ func (s *Service) Create(ctx context.Context, formInput *model.FormCreateInput,
 			if errors.Is(err, errs.ErrNoAvailableExecutors) {
 				return errs.ErrNoAvailableExecutors
 			}
-			log.Printf("failed to select optimal HR: %v", err)
+			logger.FromContext(ctx).Error("failed to select optimal HR", "error", err)
 			return errs.ErrInternalServer
 		}
 
@@ -70,7 +89,7 @@ func (s *Service) Create(ctx context.Context, formInput *model.FormCreateInput,
 		)
 
 		if err := s.formRepo.Create(txCtx, &form); err != nil {
-			log.Printf("failed to create form for user %s: %v", userID, err)
+			logger.FromContext(ctx).Error("failed to create form", "error", err)
 			return errs.ErrInternalServer
 		}
 		resultForm = &form
@@ -78,16 +97,87 @@ func (s *Service) Create(ctx context.Context, formInput *model.FormCreateInput,
 	}); err != nil {
 		return nil, err
 	}
+
+	s.metrics.FormCreated()
+	s.notifier.FormAssigned(ctx, resultForm)
+
 	return resultForm, nil
 }
 
+// ReassignPendingForms spreads the pending forms of an HR over the other active HRs
+// by workload, e.g. when the HR is deactivated or changes role. It returns the number
+// of forms moved, or errs.ErrNoAvailableExecutors when nobody can take them over.
+func (s *Service) ReassignPendingForms(ctx context.Context, executorID uuid.UUID) (int, error) {
+	ctx, span := tracing.Start(ctx, "form.Service.ReassignPendingForms")
+	defer span.End()
+
+	var reassigned []*domain.Form
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		pending := domain.StatusPending
+		forms, err := s.formRepo.FindByFilter(txCtx, &Filter{ExecutorID: &executorID, FormStatus: &pending})
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to find pending forms", "executor_id", executorID, "error", err)
+			return errs.ErrInternalServer
+		}
+		if len(forms) == 0 {
+			return nil
+		}
+
+		hrs, err := s.userRepo.FindActiveHRsWithWorkload(txCtx)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to find active HRs", "error", err)
+			return errs.ErrInternalServer
+		}
+		hrs = slices.DeleteFunc(hrs, func(hr assignment.HRWorkload) bool {
+			return hr.UserID == executorID
+		})
+
+		for i := range forms {
+			newExecutorID, err := assignment.SelectOptimalHR(hrs)
+			if err != nil {
+				return err
+			}
+
+			if !forms[i].Reassign(newExecutorID) {
+				continue
+			}
+			if err := s.formRepo.Update(txCtx, &forms[i]); err != nil {
+				if errors.Is(err, errs.ErrPreconditionFailed) {
+					return errs.ErrPreconditionFailed
+				}
+				logger.FromContext(ctx).Error("failed to reassign form", "form_id", forms[i].ID, "error", err)
+				return errs.ErrInternalServer
+			}
+			reassigned = append(reassigned, &forms[i])
+
+			for j := range hrs {
+				if hrs[j].UserID == newExecutorID {
+					hrs[j].PendingFormsCount++
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	for _, form := range reassigned {
+		s.notifier.FormAssigned(ctx, form)
+	}
+
+	return len(reassigned), nil
+}
+
 func (s *Service) GetForm(ctx context.Context, formID uuid.UUID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.Form, error) {
+	ctx, span := tracing.Start(ctx, "form.Service.GetForm")
+	defer span.End()
+
 	form, err := s.formRepo.FindByFormID(ctx, formID)
 	if err != nil {
 		if errors.Is(err, errs.ErrFormNotFound) {
 			return nil, errs.ErrFormNotFound
 		}
-		log.Printf("failed to find form: %v", err)
+		logger.FromContext(ctx).Error("failed to find form", "form_id", formID, "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
@@ -116,6 +206,9 @@ func (s *Service) GetForm(ctx context.Context, formID uuid.UUID, requesterID uui
 // For HR: can only access forms assigned to them (as executor)
 // For Admin: can access all forms with optional filtering
 func (s *Service) GetForms(ctx context.Context, filter *Filter, requesterID uuid.UUID, requesterRole domain.Role) ([]domain.Form, error) {
+	ctx, span := tracing.Start(ctx, "form.Service.GetForms")
+	defer span.End()
+
 	// Access control logic
 	switch requesterRole {
 	case domain.RoleEmployee:
@@ -138,7 +231,7 @@ func (s *Service) GetForms(ctx context.Context, filter *Filter, requesterID uuid
 				if errors.Is(err, errs.ErrUserNotFound) {
 					return nil, errs.ErrUserNotFound
 				}
-				log.Printf("failed to find user by ID: %v", err)
+				logger.FromContext(ctx).Error("failed to find user", "target_user_id", *filter.UserID, "error", err)
 				return nil, errs.ErrInternalServer
 			}
 		}
@@ -152,7 +245,7 @@ func (s *Service) GetForms(ctx context.Context, filter *Filter, requesterID uuid
 				if errors.Is(err, errs.ErrUserNotFound) {
 					return nil, errs.ErrUserNotFound
 				}
-				log.Printf("failed to find user by ID: %v", err)
+				logger.FromContext(ctx).Error("failed to find user", "target_user_id", *filter.UserID, "error", err)
 				return nil, errs.ErrInternalServer
 			}
 		}
@@ -169,7 +262,7 @@ func (s *Service) GetForms(ctx context.Context, filter *Filter, requesterID uuid
 	// Fetch forms from repository
 	forms, err := s.formRepo.FindByFilter(ctx, filter)
 	if err != nil {
-		log.Printf("failed to find forms: %v", err)
+		logger.FromContext(ctx).Error("failed to find forms", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
@@ -182,6 +275,9 @@ func (s *Service) GetForms(ctx context.Context, filter *Filter, requesterID uuid
 }
 
 func (s *Service) GetFormsWithUsers(ctx context.Context, filter *Filter, requesterID uuid.UUID, requesterRole domain.Role) ([]model.FormsWithUser, error) {
+	ctx, span := tracing.Start(ctx, "form.Service.GetFormsWithUsers")
+	defer span.End()
+
 	switch requesterRole {
 	case domain.RoleEmployee:
 		return nil, errs.ErrForbidden
@@ -204,7 +300,7 @@ func (s *Service) GetFormsWithUsers(ctx context.Context, filter *Filter, request
 			if errors.Is(err, errs.ErrUserNotFound) {
 				return nil, errs.ErrUserNotFound
 			}
-			log.Printf("failed to find user by ID: %v", err)
+			logger.FromContext(ctx).Error("failed to find user", "target_user_id", *filter.UserID, "error", err)
 			return nil, errs.ErrInternalServer
 		}
 	}
@@ -217,7 +313,7 @@ func (s *Service) GetFormsWithUsers(ctx context.Context, filter *Filter, request
 	// Fetch forms with filter
 	forms, err := s.formRepo.FindByFilter(ctx, filter)
 	if err != nil {
-		log.Printf("failed to find forms: %v", err)
+		logger.FromContext(ctx).Error("failed to find forms", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
@@ -239,7 +335,7 @@ func (s *Service) GetFormsWithUsers(ctx context.Context, filter *Filter, request
 	// Fetch users
 	users, err := s.userRepo.FindByUserIDs(ctx, userIDs)
 	if err != nil {
-		log.Printf("failed to find users: %v", err)
+		logger.FromContext(ctx).Error("failed to find users", "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
@@ -269,16 +365,23 @@ func (s *Service) GetFormsWithUsers(ctx context.Context, filter *Filter, request
 	return result, nil
 }
 
-func (s *Service) Approve(ctx context.Context, formID uuid.UUID, comment string) (*domain.Form, error) {
+func (s *Service) Approve(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error) {
+	ctx, span := tracing.Start(ctx, "form.Service.Approve")
+	defer span.End()
+
 	form, err := s.formRepo.FindByFormID(ctx, formID)
 	if err != nil {
 		if errors.Is(err, errs.ErrFormNotFound) {
 			return nil, errs.ErrFormNotFound
 		}
-		log.Printf("Failed to find form: %v", err)
+		logger.FromContext(ctx).Error("failed to find form", "form_id", formID, "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
+	if expectedVersion != nil && *expectedVersion != form.Version {
+		return nil, errs.ErrPreconditionFailed
+	}
+
 	changed, err := form.ApproveForm(comment)
 	if err != nil {
 		return nil, err
@@ -286,24 +389,36 @@ func (s *Service) Approve(ctx context.Context, formID uuid.UUID, comment string)
 
 	if changed {
 		if err := s.formRepo.Update(ctx, form); err != nil {
-			log.Printf("Failed to update form: %v", err)
+			if errors.Is(err, errs.ErrPreconditionFailed) {
+				return nil, errs.ErrPreconditionFailed
+			}
+			logger.FromContext(ctx).Error("failed to update form", "form_id", formID, "error", err)
 			return nil, errs.ErrInternalServer
 		}
+		s.metrics.FormReviewed(form)
+		s.notifier.FormReviewed(ctx, form)
 	}
 
 	return form, nil
 }
 
-func (s *Service) Reject(ctx context.Context, formID uuid.UUID, comment string) (*domain.Form, error) {
+func (s *Service) Reject(ctx context.Context, formID uuid.UUID, comment string, expectedVersion *int) (*domain.Form, error) {
+	ctx, span := tracing.Start(ctx, "form.Service.Reject")
+	defer span.End()
+
 	form, err := s.formRepo.FindByFormID(ctx, formID)
 	if err != nil {
 		if errors.Is(err, errs.ErrFormNotFound) {
 			return nil, errs.ErrFormNotFound
 		}
-		log.Printf("Failed to find form: %v", err)
+		logger.FromContext(ctx).Error("failed to find form", "form_id", formID, "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
+	if expectedVersion != nil && *expectedVersion != form.Version {
+		return nil, errs.ErrPreconditionFailed
+	}
+
 	changed, err := form.RejectForm(comment)
 	if err != nil {
 		return nil, err
@@ -311,9 +426,14 @@ func (s *Service) Reject(ctx context.Context, formID uuid.UUID, comment string)
 
 	if changed {
 		if err := s.formRepo.Update(ctx, form); err != nil {
-			log.Printf("Failed to update form: %v", err)
+			if errors.Is(err, errs.ErrPreconditionFailed) {
+				return nil, errs.ErrPreconditionFailed
+			}
+			logger.FromContext(ctx).Error("failed to update form", "form_id", formID, "error", err)
 			return nil, errs.ErrInternalServer
 		}
+		s.metrics.FormReviewed(form)
+		s.notifier.FormReviewed(ctx, form)
 	}
 
 	return form, nil
@@ -0,0 +1,31 @@
+package model
+
+const (
+	CheckDatabase   = "database"
+	CheckMigrations = "migrations"
+	CheckWorkers    = "workers"
+	CheckShutdown   = "shutdown"
+)
+
+type Check struct {
+	Name string
+	// Err is empty for a passing check
+	Err string
+}
+
+func (c Check) Healthy() bool {
+	return c.Err == ""
+}
+
+type Report struct {
+	Checks []Check
+}
+
+func (r *Report) Ready() bool {
+	for _, check := range r.Checks {
+		if !check.Healthy() {
+			return false
+		}
+	}
+	return true
+}
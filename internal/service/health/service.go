@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/service/health/model"
+)
+
+// checkTimeout keeps a slow database from holding up the probe longer than the orchestrator waits
+const checkTimeout = 2 * time.Second
+
+type Repository interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (int64, error)
+}
+
+type Workers interface {
+	Running() bool
+}
+
+type Service struct {
+	repo            Repository
+	workers         Workers
+	expectedVersion int64
+	draining        atomic.Bool
+}
+
+func NewService(repo Repository, workers Workers, expectedVersion int64) *Service {
+	return &Service{
+		repo:            repo,
+		workers:         workers,
+		expectedVersion: expectedVersion,
+	}
+}
+
+// Drain makes the instance report itself as not ready, so load balancers
+// stop routing to it before the server shuts down.
+func (s *Service) Drain() {
+	s.draining.Store(true)
+}
+
+// Readiness reports whether the instance can serve traffic.
+func (s *Service) Readiness(ctx context.Context) *model.Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := &model.Report{}
+
+	if s.draining.Load() {
+		report.Checks = append(report.Checks, model.Check{Name: model.CheckShutdown, Err: "shutting down"})
+	}
+
+	database := model.Check{Name: model.CheckDatabase}
+	if err := s.repo.Ping(ctx); err != nil {
+		logger.FromContext(ctx).Warn("readiness: database ping failed", "error", err)
+		database.Err = "database is unreachable"
+	}
+	report.Checks = append(report.Checks, database)
+
+	migrations := model.Check{Name: model.CheckMigrations}
+	if database.Healthy() {
+		version, err := s.repo.MigrationVersion(ctx)
+		switch {
+		case err != nil:
+			logger.FromContext(ctx).Warn("readiness: failed to get migration version", "error", err)
+			migrations.Err = "migration version is unknown"
+		// A newer schema is fine: migrations run before the new release replaces this one
+		case version < s.expectedVersion:
+			migrations.Err = fmt.Sprintf("database is at migration %d, expected %d", version, s.expectedVersion)
+		}
+	} else {
+		migrations.Err = "database is unreachable"
+	}
+	report.Checks = append(report.Checks, migrations)
+
+	workers := model.Check{Name: model.CheckWorkers}
+	if !s.workers.Running() {
+		workers.Err = "background workers are not running"
+	}
+	report.Checks = append(report.Checks, workers)
+
+	return report
+}
@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+type Repository interface {
+	Reserve(ctx context.Context, key *domain.IdempotencyKey, staleBefore time.Time) (bool, *domain.IdempotencyKey, error)
+	Complete(ctx context.Context, key *domain.IdempotencyKey) error
+	Delete(ctx context.Context, userID uuid.UUID, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type Service struct {
+	repo        Repository
+	ttl         time.Duration
+	lockTimeout time.Duration
+}
+
+// NewService creates the service. Keys are kept for ttl; a request that has not
+// finished within lockTimeout is considered abandoned and its key can be reused.
+func NewService(repo Repository, ttl, lockTimeout time.Duration) *Service {
+	return &Service{
+		repo:        repo,
+		ttl:         ttl,
+		lockTimeout: lockTimeout,
+	}
+}
+
+// Begin reserves the key for a request. It returns the stored response when the key has
+// already been used for the same request, and nil when the caller should process it.
+func (s *Service) Begin(ctx context.Context, userID uuid.UUID, key, method, path, fingerprint string) (*domain.IdempotencyKey, error) {
+	ctx, span := tracing.Start(ctx, "idempotency.Service.Begin")
+	defer span.End()
+
+	entry := domain.NewIdempotencyKey(userID, key, method, path, fingerprint, s.ttl)
+
+	reserved, existing, err := s.repo.Reserve(ctx, &entry, entry.CreatedAt.Add(-s.lockTimeout))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to reserve idempotency key", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+	if reserved {
+		return nil, nil
+	}
+	if existing == nil {
+		// The previous entry was removed concurrently, the client may retry
+		return nil, errs.ErrIdempotencyKeyInUse
+	}
+
+	if existing.Method != method || existing.Path != path || existing.Fingerprint != fingerprint {
+		return nil, errs.ErrIdempotencyKeyMismatch
+	}
+	if !existing.IsCompleted() {
+		return nil, errs.ErrIdempotencyKeyInUse
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response of a request started with Begin.
+func (s *Service) Complete(ctx context.Context, userID uuid.UUID, key string, statusCode int, contentType string, body []byte) {
+	ctx, span := tracing.Start(ctx, "idempotency.Service.Complete")
+	defer span.End()
+
+	entry := domain.IdempotencyKey{UserID: userID, Key: key}
+	entry.Complete(statusCode, contentType, body)
+
+	if err := s.repo.Complete(ctx, &entry); err != nil {
+		logger.FromContext(ctx).Error("failed to store response for idempotency key", "error", err)
+	}
+}
+
+// Release frees the key so that the request can be retried, e.g. after a server error.
+func (s *Service) Release(ctx context.Context, userID uuid.UUID, key string) {
+	ctx, span := tracing.Start(ctx, "idempotency.Service.Release")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, userID, key); err != nil {
+		logger.FromContext(ctx).Error("failed to release idempotency key", "error", err)
+	}
+}
+
+// RunCleanup removes expired keys every interval until ctx is cancelled.
+func (s *Service) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.repo.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to delete expired idempotency keys", "error", err)
+				continue
+			}
+			if deleted > 0 {
+				logger.FromContext(ctx).Info("expired idempotency keys deleted", "count", deleted)
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	"github.com/platonso/hrmate/internal/i18n"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+const dateLayout = "02.01.2006"
+
+type UserRepository interface {
+	FindByUserID(ctx context.Context, userId uuid.UUID) (*domain.User, error)
+}
+
+// Service notifies users about events in their own language.
+// Delivery failures are logged and never fail the operation that caused them.
+type Service struct {
+	sender Sender
+	users  UserRepository
+}
+
+func NewService(sender Sender, users UserRepository) *Service {
+	return &Service{
+		sender: sender,
+		users:  users,
+	}
+}
+
+type formData struct {
+	RecipientName string
+	AuthorName    string
+	Title         string
+	Period        string
+	Status        string
+	Comment       string
+}
+
+// FormAssigned tells the HR specialist about a new form to review.
+func (s *Service) FormAssigned(ctx context.Context, form *domain.Form) {
+	ctx, span := tracing.Start(ctx, "notification.Service.FormAssigned")
+	defer span.End()
+
+	executor, ok := s.recipient(ctx, form.ExecutorID)
+	if !ok {
+		return
+	}
+
+	author, err := s.users.FindByUserID(ctx, form.UserID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find form author", "target_user_id", form.UserID, "form_id", form.ID, "error", err)
+		return
+	}
+
+	s.send(ctx, executor, "form_assigned", false, formData{
+		RecipientName: executor.FirstName,
+		AuthorName:    strings.TrimSpace(author.FirstName + " " + author.LastName),
+		Title:         form.Title,
+		Period:        period(form),
+	})
+}
+
+// FormReviewed tells the author that the form was approved or rejected.
+func (s *Service) FormReviewed(ctx context.Context, form *domain.Form) {
+	ctx, span := tracing.Start(ctx, "notification.Service.FormReviewed")
+	defer span.End()
+
+	author, ok := s.recipient(ctx, form.UserID)
+	if !ok {
+		return
+	}
+
+	data := formData{
+		RecipientName: author.FirstName,
+		Title:         form.Title,
+		Period:        period(form),
+		Status:        i18n.T(locale(author), "form.status."+string(form.Status)),
+	}
+	if form.Comment != nil {
+		data.Comment = *form.Comment
+	}
+
+	s.send(ctx, author, "form_reviewed", false, data)
+}
+
+type invitationData struct {
+	RecipientName string
+	Email         string
+	Password      string
+}
+
+// UserInvited sends an imported user the temporary password of their account.
+func (s *Service) UserInvited(ctx context.Context, user *domain.User, password string) {
+	ctx, span := tracing.Start(ctx, "notification.Service.UserInvited")
+	defer span.End()
+
+	s.send(ctx, user, "user_invited", true, invitationData{
+		RecipientName: user.FirstName,
+		Email:         user.Email,
+		Password:      password,
+	})
+}
+
+// Delivers reports whether notifications reach the users rather than only the log.
+func (s *Service) Delivers() bool {
+	_, logOnly := s.sender.(LogSender)
+	return !logOnly
+}
+
+func (s *Service) recipient(ctx context.Context, userID uuid.UUID) (*domain.User, bool) {
+	user, err := s.users.FindByUserID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find notification recipient", "target_user_id", userID, "error", err)
+		return nil, false
+	}
+
+	// Integrations have no mailbox
+	if user.IsServiceAccount || user.Email == "" {
+		return nil, false
+	}
+
+	return user, true
+}
+
+func (s *Service) send(ctx context.Context, to *domain.User, name string, sensitive bool, data any) {
+	loc := locale(to)
+
+	subject, err := render(loc, "notification."+name+".subject", data)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to render notification", "notification", name, "error", err)
+		return
+	}
+
+	body, err := render(loc, "notification."+name+".body", data)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to render notification", "notification", name, "error", err)
+		return
+	}
+
+	if err := s.sender.Send(ctx, Message{
+		To:        to.Email,
+		Locale:    loc,
+		Subject:   subject,
+		Body:      body,
+		Sensitive: sensitive,
+	}); err != nil {
+		logger.FromContext(ctx).Error("failed to send notification", "notification", name, "target_user_id", to.ID, "error", err)
+	}
+}
+
+func render(locale, key string, data any) (string, error) {
+	text, ok := i18n.Lookup(locale, key)
+	if !ok {
+		return "", fmt.Errorf("no template %s", key)
+	}
+
+	tmpl, err := template.New(key).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse template %s: %w", key, err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("execute template %s: %w", key, err)
+	}
+
+	return b.String(), nil
+}
+
+func locale(user *domain.User) string {
+	if user.Locale != "" {
+		return user.Locale
+	}
+	return i18n.DefaultLocale
+}
+
+func period(form *domain.Form) string {
+	switch {
+	case form.StartDate != nil && form.EndDate != nil:
+		return form.StartDate.Format(dateLayout) + " – " + form.EndDate.Format(dateLayout)
+	case form.StartDate != nil:
+		return form.StartDate.Format(dateLayout)
+	default:
+		return ""
+	}
+}
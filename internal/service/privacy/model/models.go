@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+)
+
+// Export is the personal data held about a user, as handed to them on request.
+type Export struct {
+	ExportedAt time.Time
+	User       domain.User
+	// Forms are the requests the user submitted, with the comments of their reviewers
+	Forms []domain.Form
+	// Reviews are the decisions the user made on requests assigned to them
+	Reviews []Review
+	// AuditEntries are the requests the user made or that concerned them
+	AuditEntries []domain.AuditEntry
+}
+
+// Review is what a reviewer contributed to a form. The form itself belongs to its
+// author and is not part of the reviewer's data.
+type Review struct {
+	FormID     uuid.UUID
+	Status     domain.FormStatus
+	ReviewedAt *time.Time
+	Comment    *string
+}
+
+// ErasureResult counts what was erased along with the profile.
+type ErasureResult struct {
+	Forms        int64
+	AuditEntries int64
+	// Records are the sessions, API tokens, linked identities and stored responses deleted
+	Records int64
+}
@@ -0,0 +1,200 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	auditservice "github.com/platonso/hrmate/internal/service/audit"
+	formservice "github.com/platonso/hrmate/internal/service/form"
+	"github.com/platonso/hrmate/internal/service/privacy/model"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+type UserRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
+}
+
+type FormRepository interface {
+	FindByFilter(ctx context.Context, filter *formservice.Filter) ([]domain.Form, error)
+	EraseByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+type AuditRepository interface {
+	FindByFilter(ctx context.Context, filter *auditservice.Filter) ([]domain.AuditEntry, error)
+	AnonymizeByActor(ctx context.Context, actorID uuid.UUID) (int64, error)
+}
+
+// RecordRepository deletes records that only matter to the user, e.g. their sessions.
+type RecordRepository interface {
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// Deactivator deactivates the user, keeping an administrator and handing their
+// pending forms over, and revokes their sessions.
+type Deactivator interface {
+	ChangeActiveStatus(ctx context.Context, userID uuid.UUID, isActive bool, expectedVersion *int) (*domain.User, error)
+}
+
+// Service exports and erases the personal data of users, as the GDPR requires.
+type Service struct {
+	txMgr       trm.Manager
+	users       UserRepository
+	forms       FormRepository
+	audit       AuditRepository
+	records     []RecordRepository
+	deactivator Deactivator
+	legalHolds  domain.LegalHolds
+}
+
+func NewService(
+	txMgr trm.Manager,
+	users UserRepository,
+	forms FormRepository,
+	audit AuditRepository,
+	records []RecordRepository,
+	deactivator Deactivator,
+	legalHolds domain.LegalHolds,
+) *Service {
+	return &Service{
+		txMgr:       txMgr,
+		users:       users,
+		forms:       forms,
+		audit:       audit,
+		records:     records,
+		deactivator: deactivator,
+		legalHolds:  legalHolds,
+	}
+}
+
+// ExportData collects the data held about the user.
+func (s *Service) ExportData(ctx context.Context, userID uuid.UUID) (*model.Export, error) {
+	ctx, span := tracing.Start(ctx, "privacy.Service.ExportData")
+	defer span.End()
+
+	user, err := s.users.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find user", "target_user_id", userID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	forms, err := s.forms.FindByFilter(ctx, &formservice.Filter{UserID: &userID})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find forms", "target_user_id", userID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	reviewed, err := s.forms.FindByFilter(ctx, &formservice.Filter{ExecutorID: &userID})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find reviewed forms", "target_user_id", userID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	entries, err := s.audit.FindByFilter(ctx, &auditservice.Filter{InvolvedUserID: &userID})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find audit entries", "target_user_id", userID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	return &model.Export{
+		ExportedAt:   time.Now(),
+		User:         *user,
+		Forms:        forms,
+		Reviews:      toReviews(reviewed),
+		AuditEntries: entries,
+	}, nil
+}
+
+// EraseUser anonymizes the user and the free text of their forms, and deletes their
+// sessions, tokens and linked identities. The account and the forms are kept, so
+// references and statistics stay intact; audit entries lose the IP address. Users
+// under legal hold cannot be erased, nor can service accounts or the last admin.
+func (s *Service) EraseUser(ctx context.Context, userID uuid.UUID, expectedVersion *int) (*model.ErasureResult, error) {
+	ctx, span := tracing.Start(ctx, "privacy.Service.EraseUser")
+	defer span.End()
+
+	if err := s.legalHolds.Check(userID); err != nil {
+		return nil, err
+	}
+
+	result := &model.ErasureResult{}
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		user, err := s.users.FindByUserID(txCtx, userID)
+		if err != nil {
+			if errors.Is(err, errs.ErrUserNotFound) {
+				return errs.ErrUserNotFound
+			}
+			logger.FromContext(ctx).Error("failed to find user", "target_user_id", userID, "error", err)
+			return errs.ErrInternalServer
+		}
+
+		if user.IsServiceAccount {
+			return errs.ErrInvalidRequest
+		}
+		if expectedVersion != nil && *expectedVersion != user.Version {
+			return errs.ErrPreconditionFailed
+		}
+
+		if user, err = s.deactivator.ChangeActiveStatus(txCtx, userID, false, nil); err != nil {
+			return err
+		}
+
+		user.Erase()
+		if err := s.users.Update(txCtx, user); err != nil {
+			logger.FromContext(ctx).Error("failed to erase user", "target_user_id", userID, "error", err)
+			return errs.ErrInternalServer
+		}
+
+		if result.Forms, err = s.forms.EraseByUserID(txCtx, userID); err != nil {
+			logger.FromContext(ctx).Error("failed to erase forms", "target_user_id", userID, "error", err)
+			return errs.ErrInternalServer
+		}
+
+		if result.AuditEntries, err = s.audit.AnonymizeByActor(txCtx, userID); err != nil {
+			logger.FromContext(ctx).Error("failed to anonymize audit entries", "target_user_id", userID, "error", err)
+			return errs.ErrInternalServer
+		}
+
+		for _, repo := range s.records {
+			count, err := repo.DeleteByUserID(txCtx, userID)
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to delete records of user", "target_user_id", userID, "error", err)
+				return errs.ErrInternalServer
+			}
+			result.Records += count
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("user erased",
+		"target_user_id", userID,
+		"forms", result.Forms,
+		"audit_entries", result.AuditEntries,
+		"records", result.Records,
+	)
+	return result, nil
+}
+
+func toReviews(forms []domain.Form) []model.Review {
+	reviews := make([]model.Review, len(forms))
+	for i := range forms {
+		reviews[i] = model.Review{
+			FormID:     forms[i].ID,
+			Status:     forms[i].Status,
+			ReviewedAt: forms[i].ReviewedAt,
+			Comment:    forms[i].Comment,
+		}
+	}
+	return reviews
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+// Store keeps the token buckets. MemoryStore suits a single instance,
+// PostgresStore shares the buckets between replicas.
+type Store interface {
+	Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error)
+	DeleteIdle(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Service struct {
+	store  Store
+	limits map[domain.RateLimitGroup]domain.RateLimit
+}
+
+// NewService creates the service. Groups missing from limits are not limited.
+func NewService(store Store, limits map[domain.RateLimitGroup]domain.RateLimit) *Service {
+	return &Service{
+		store:  store,
+		limits: limits,
+	}
+}
+
+// Allow takes a request of the subject, e.g. a user or an IP address, from the bucket of the group.
+// It returns nil when the group is not limited.
+func (s *Service) Allow(ctx context.Context, group domain.RateLimitGroup, subject string) (*domain.RateLimitDecision, error) {
+	limit, ok := s.limits[group]
+	if !ok || !limit.Enabled() {
+		return nil, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "ratelimit.Service.Allow")
+	defer span.End()
+
+	decision, err := s.store.Take(ctx, string(group)+":"+subject, limit, time.Now())
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to take rate limit token", "group", group, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	return &decision, nil
+}
+
+// RunCleanup forgets idle clients every interval until ctx is cancelled. A bucket idle
+// for longer than the longest period is full, so dropping it changes nothing.
+func (s *Service) RunCleanup(ctx context.Context, interval time.Duration) {
+	var idle time.Duration
+	for _, limit := range s.limits {
+		idle = max(idle, limit.Period)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.store.DeleteIdle(ctx, time.Now().Add(-idle))
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to delete idle rate limit buckets", "error", err)
+				continue
+			}
+			if deleted > 0 {
+				logger.FromContext(ctx).Debug("idle rate limit buckets deleted", "count", deleted)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/platonso/hrmate/internal/domain"
+)
+
+// MemoryStore keeps the buckets in the process, so every replica limits on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*domain.TokenBucket
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*domain.TokenBucket),
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		b := domain.NewTokenBucket(key, limit, now)
+		bucket = &b
+		s.buckets[key] = bucket
+	}
+
+	return bucket.Take(limit, now), nil
+}
+
+func (s *MemoryStore) DeleteIdle(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, bucket := range s.buckets {
+		if bucket.UpdatedAt.Before(before) {
+			delete(s.buckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type Repository interface {
+	Lock(ctx context.Context, initial domain.TokenBucket) (*domain.TokenBucket, error)
+	Save(ctx context.Context, bucket *domain.TokenBucket) error
+	DeleteIdle(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PostgresStore shares the buckets between replicas. Each request locks its bucket row
+// for a short transaction.
+type PostgresStore struct {
+	txMgr trm.Manager
+	repo  Repository
+}
+
+func NewPostgresStore(txMgr trm.Manager, repo Repository) *PostgresStore {
+	return &PostgresStore{
+		txMgr: txMgr,
+		repo:  repo,
+	}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	var decision domain.RateLimitDecision
+
+	err := s.txMgr.Do(ctx, func(ctx context.Context) error {
+		bucket, err := s.repo.Lock(ctx, domain.NewTokenBucket(key, limit, now))
+		if err != nil {
+			return err
+		}
+
+		decision = bucket.Take(limit, now)
+		return s.repo.Save(ctx, bucket)
+	})
+
+	return decision, err
+}
+
+func (s *PostgresStore) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.DeleteIdle(ctx, before)
+}
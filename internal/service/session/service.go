@@ -0,0 +1,131 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+type Repository interface {
+	FindByID(ctx context.Context, sessionID uuid.UUID) (*domain.Session, error)
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	Touch(ctx context.Context, session *domain.Session) error
+	Revoke(ctx context.Context, sessionID uuid.UUID, revokedAt time.Time) error
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int64, error)
+}
+
+type UserRepository interface {
+	FindByUserID(ctx context.Context, userId uuid.UUID) (*domain.User, error)
+}
+
+type Service struct {
+	sessionRepo Repository
+	userRepo    UserRepository
+}
+
+func NewService(sessionRepo Repository, userRepo UserRepository) *Service {
+	return &Service{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Validate checks that the session is still active and records the activity.
+func (s *Service) Validate(ctx context.Context, sessionID, userID uuid.UUID, ipAddress string) error {
+	ctx, span := tracing.Start(ctx, "session.Service.Validate")
+	defer span.End()
+
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrSessionNotFound) {
+			return errs.ErrUnauthorized
+		}
+		logger.FromContext(ctx).Error("failed to find session", "session_id", sessionID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	now := time.Now()
+	if session.UserID != userID || !session.IsActive(now) {
+		return errs.ErrUnauthorized
+	}
+
+	if session.Touch(now, ipAddress) {
+		if err := s.sessionRepo.Touch(ctx, session); err != nil {
+			// Revoked between the read and the write
+			if errors.Is(err, errs.ErrSessionNotFound) {
+				return errs.ErrUnauthorized
+			}
+			logger.FromContext(ctx).Warn("failed to update session", "session_id", sessionID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) GetSessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	ctx, span := tracing.Start(ctx, "session.Service.GetSessions")
+	defer span.End()
+
+	sessions, err := s.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find sessions", "target_user_id", userID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	return sessions, nil
+}
+
+// Revoke ends a session of the user. Sessions of other users are reported as not found.
+func (s *Service) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "session.Service.Revoke")
+	defer span.End()
+
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrSessionNotFound) {
+			return errs.ErrSessionNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find session", "session_id", sessionID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	if session.UserID != userID {
+		return errs.ErrSessionNotFound
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, sessionID, time.Now()); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke session", "session_id", sessionID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// RevokeAll logs the user out everywhere.
+func (s *Service) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "session.Service.RevokeAll")
+	defer span.End()
+
+	if _, err := s.userRepo.FindByUserID(ctx, userID); err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return errs.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find user", "target_user_id", userID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	revoked, err := s.sessionRepo.RevokeAllByUserID(ctx, userID, time.Now())
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to revoke sessions", "target_user_id", userID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	logger.FromContext(ctx).Info("sessions revoked", "target_user_id", userID, "count", revoked)
+	return nil
+}
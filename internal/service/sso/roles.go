@@ -0,0 +1,78 @@
+package sso
+
+import (
+	"fmt"
+
+	"github.com/platonso/hrmate/internal/domain"
+)
+
+var rolePriority = map[domain.Role]int{
+	domain.RoleEmployee: 1,
+	domain.RoleHR:       2,
+	domain.RoleAdmin:    3,
+}
+
+// RoleMapper maps values of a provider claim (e.g. group names) to hrmate roles.
+type RoleMapper struct {
+	claim       string
+	rules       map[string]domain.Role
+	defaultRole domain.Role
+}
+
+func NewRoleMapper(claim string, rules map[string]string, defaultRole string) (*RoleMapper, error) {
+	mapper := &RoleMapper{
+		claim:       claim,
+		rules:       make(map[string]domain.Role, len(rules)),
+		defaultRole: domain.Role(defaultRole),
+	}
+
+	if _, ok := rolePriority[mapper.defaultRole]; !ok {
+		return nil, fmt.Errorf("unknown default role %q", defaultRole)
+	}
+
+	for value, role := range rules {
+		if _, ok := rolePriority[domain.Role(role)]; !ok {
+			return nil, fmt.Errorf("unknown role %q in mapping for %q", role, value)
+		}
+		mapper.rules[value] = domain.Role(role)
+	}
+
+	return mapper, nil
+}
+
+// Map returns the most privileged role matched by the claim values,
+// or the default role when nothing matches.
+func (m *RoleMapper) Map(claims map[string]any) domain.Role {
+	role := m.defaultRole
+	matched := false
+
+	for _, value := range claimValues(claims[m.claim]) {
+		mapped, ok := m.rules[value]
+		if !ok {
+			continue
+		}
+		if !matched || rolePriority[mapped] > rolePriority[role] {
+			role = mapped
+			matched = true
+		}
+	}
+
+	return role
+}
+
+func claimValues(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
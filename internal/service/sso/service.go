@@ -0,0 +1,331 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
+	"golang.org/x/oauth2"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *domain.User) error
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindByUserID(ctx context.Context, userId uuid.UUID) (*domain.User, error)
+}
+
+type IdentityRepository interface {
+	Create(ctx context.Context, identity *domain.Identity) error
+	FindByIssuerSubject(ctx context.Context, issuer, subject string) (*domain.Identity, error)
+}
+
+type StateRepository interface {
+	Create(ctx context.Context, state *domain.AuthState) error
+	Consume(ctx context.Context, state string) (*domain.AuthState, error)
+}
+
+type TokenIssuer interface {
+	IssueToken(ctx context.Context, user *domain.User, client domain.ClientInfo) (string, error)
+}
+
+type Settings struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	StateTTL     time.Duration
+}
+
+type Service struct {
+	settings   Settings
+	roles      *RoleMapper
+	txMgr      trm.Manager
+	users      UserRepository
+	identities IdentityRepository
+	states     StateRepository
+	tokens     TokenIssuer
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func NewService(
+	settings Settings,
+	roles *RoleMapper,
+	txMgr trm.Manager,
+	users UserRepository,
+	identities IdentityRepository,
+	states StateRepository,
+	tokens TokenIssuer,
+) *Service {
+	return &Service{
+		settings:   settings,
+		roles:      roles,
+		txMgr:      txMgr,
+		users:      users,
+		identities: identities,
+		states:     states,
+		tokens:     tokens,
+	}
+}
+
+// BeginLogin starts an authorization-code + PKCE flow and returns the provider URL
+// the user agent has to be redirected to.
+func (s *Service) BeginLogin(ctx context.Context) (string, error) {
+	ctx, span := tracing.Start(ctx, "sso.Service.BeginLogin")
+	defer span.End()
+
+	provider, err := s.getProvider(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to discover OIDC provider", "error", err)
+		return "", errs.ErrInternalServer
+	}
+
+	state, err := randomString()
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate OIDC state", "error", err)
+		return "", errs.ErrInternalServer
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate OIDC nonce", "error", err)
+		return "", errs.ErrInternalServer
+	}
+
+	authState := domain.AuthState{
+		State:        state,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(s.settings.StateTTL),
+	}
+
+	if err := s.states.Create(ctx, &authState); err != nil {
+		logger.FromContext(ctx).Error("failed to store OIDC state", "error", err)
+		return "", errs.ErrInternalServer
+	}
+
+	return s.oauth2Config(provider).AuthCodeURL(
+		authState.State,
+		oauth2.S256ChallengeOption(authState.CodeVerifier),
+		oidc.Nonce(authState.Nonce),
+	), nil
+}
+
+// CompleteLogin exchanges the authorization code, verifies the ID token and
+// returns an hrmate access token for the linked or just-in-time provisioned user.
+func (s *Service) CompleteLogin(ctx context.Context, code, state string, client domain.ClientInfo) (string, error) {
+	ctx, span := tracing.Start(ctx, "sso.Service.CompleteLogin")
+	defer span.End()
+
+	authState, err := s.states.Consume(ctx, state)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidAuthState) {
+			return "", errs.ErrInvalidAuthState
+		}
+		logger.FromContext(ctx).Error("failed to consume OIDC state", "error", err)
+		return "", errs.ErrInternalServer
+	}
+
+	if time.Now().After(authState.ExpiresAt) {
+		return "", errs.ErrInvalidAuthState
+	}
+
+	provider, err := s.getProvider(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to discover OIDC provider", "error", err)
+		return "", errs.ErrInternalServer
+	}
+
+	oauth2Token, err := s.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(authState.CodeVerifier))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to exchange OIDC code", "error", err)
+		return "", errs.ErrInvalidCredentials
+	}
+
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		logger.FromContext(ctx).Error("OIDC token response has no id_token")
+		return "", errs.ErrInvalidCredentials
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.settings.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to verify OIDC id token", "error", err)
+		return "", errs.ErrInvalidCredentials
+	}
+
+	if idToken.Nonce != authState.Nonce {
+		return "", errs.ErrInvalidCredentials
+	}
+
+	claims := make(map[string]any)
+	if err := idToken.Claims(&claims); err != nil {
+		logger.FromContext(ctx).Error("failed to parse OIDC claims", "error", err)
+		return "", errs.ErrInvalidCredentials
+	}
+
+	// Some providers only expose profile and group claims through the userinfo endpoint
+	userInfo, err := provider.UserInfo(ctx, oauth2.StaticTokenSource(oauth2Token))
+	if err == nil {
+		extra := make(map[string]any)
+		if err := userInfo.Claims(&extra); err == nil {
+			for k, v := range extra {
+				if _, exists := claims[k]; !exists {
+					claims[k] = v
+				}
+			}
+		}
+	}
+
+	user, err := s.provision(ctx, idToken.Issuer, idToken.Subject, claims)
+	if err != nil {
+		return "", err
+	}
+
+	if !user.IsActive {
+		return "", errs.ErrUserNotActive
+	}
+
+	return s.tokens.IssueToken(ctx, user, client)
+}
+
+// provision resolves the user behind an external identity. Unknown identities are
+// linked to an existing account by verified email or provisioned as a new user.
+func (s *Service) provision(ctx context.Context, issuer, subject string, claims map[string]any) (*domain.User, error) {
+	var user *domain.User
+
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		identity, err := s.identities.FindByIssuerSubject(txCtx, issuer, subject)
+		if err == nil {
+			user, err = s.users.FindByUserID(txCtx, identity.UserID)
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to find user linked to identity", "target_user_id", identity.UserID, "error", err)
+				return errs.ErrInternalServer
+			}
+			return nil
+		}
+		if !errors.Is(err, errs.ErrIdentityNotFound) {
+			logger.FromContext(ctx).Error("failed to find identity", "error", err)
+			return errs.ErrInternalServer
+		}
+
+		email := stringClaim(claims, "email")
+		if email == "" {
+			return errs.ErrInvalidCredentials
+		}
+
+		existingUser, err := s.users.FindByEmail(txCtx, email)
+		switch {
+		case err == nil:
+			if !boolClaim(claims, "email_verified") {
+				return errs.ErrEmailNotVerified
+			}
+			user = existingUser
+
+		case errors.Is(err, errs.ErrUserNotFound):
+			firstName, lastName := names(claims)
+			newUser := domain.NewUser(s.roles.Map(claims), firstName, lastName, "", email, "")
+			// Access is granted by the identity provider, so no manual activation is required
+			newUser.Activate()
+
+			if err := s.users.Create(txCtx, &newUser); err != nil {
+				logger.FromContext(ctx).Error("failed to provision user", "error", err)
+				return errs.ErrInternalServer
+			}
+			user = &newUser
+
+		default:
+			logger.FromContext(ctx).Error("failed to find user by email", "error", err)
+			return errs.ErrInternalServer
+		}
+
+		newIdentity := domain.NewIdentity(issuer, subject, user.ID, email)
+		if err := s.identities.Create(txCtx, &newIdentity); err != nil {
+			logger.FromContext(ctx).Error("failed to link identity", "target_user_id", user.ID, "error", err)
+			return errs.ErrInternalServer
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// getProvider performs discovery lazily, so the application starts even if the provider is down.
+func (s *Service) getProvider(ctx context.Context) (*oidc.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider != nil {
+		return s.provider, nil
+	}
+
+	// The provider keeps the context for fetching signing keys later on
+	provider, err := oidc.NewProvider(context.WithoutCancel(ctx), s.settings.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.provider = provider
+	return provider, nil
+}
+
+func (s *Service) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.settings.ClientID,
+		ClientSecret: s.settings.ClientSecret,
+		RedirectURL:  s.settings.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       s.settings.Scopes,
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func stringClaim(claims map[string]any, name string) string {
+	s, _ := claims[name].(string)
+	return strings.TrimSpace(s)
+}
+
+func boolClaim(claims map[string]any, name string) bool {
+	switch v := claims[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+func names(claims map[string]any) (string, string) {
+	firstName := stringClaim(claims, "given_name")
+	lastName := stringClaim(claims, "family_name")
+
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(stringClaim(claims, "name"), " ")
+	}
+
+	return firstName, lastName
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+)
+
+type TokenCreateInput struct {
+	Name      string
+	Scopes    []domain.Scope
+	ExpiresIn time.Duration
+}
+
+// CreatedToken holds the plain token value, which is returned only once.
+type CreatedToken struct {
+	Token    domain.APIToken
+	RawToken string
+}
+
+type ServiceAccountCreateInput struct {
+	Name string
+	Role domain.Role
+}
+
+// Principal is the identity resolved from a valid API token.
+type Principal struct {
+	UserID uuid.UUID
+	Role   domain.Role
+	Scopes []domain.Scope
+}
@@ -0,0 +1,302 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/service/user/model"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+// CreateUser creates an account on behalf of an administrator, with a password
+// the user is expected to change, or without one for single sign-on.
+func (s *Service) CreateUser(ctx context.Context, input *model.CreateUserInput) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.CreateUser")
+	defer span.End()
+
+	if !validRole(input.Role) {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	if input.Password != "" {
+		if err := s.policy.Validate(input.Password, input.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := s.repo.FindByEmail(ctx, input.Email)
+	if err == nil {
+		return nil, errs.ErrUserAlreadyExists
+	}
+	if !errors.Is(err, errs.ErrUserNotFound) {
+		logger.FromContext(ctx).Error("failed to check user existence", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	var hashedPassword string
+	if input.Password != "" {
+		if hashedPassword, err = s.hasher.Hash(input.Password); err != nil {
+			logger.FromContext(ctx).Error("failed to hash password", "error", err)
+			return nil, errs.ErrInternalServer
+		}
+	}
+
+	user := domain.NewUser(input.Role, input.FirstName, input.LastName, input.Position, input.Email, hashedPassword)
+	user.Department = input.Department
+	user.IsActive = input.IsActive
+
+	if input.ManagerID != nil {
+		if err := s.checkManager(ctx, user.ID, *input.ManagerID); err != nil {
+			return nil, err
+		}
+		user.ManagerID = input.ManagerID
+	}
+
+	if err := s.repo.Create(ctx, &user); err != nil {
+		if errors.Is(err, errs.ErrUserAlreadyExists) {
+			return nil, errs.ErrUserAlreadyExists
+		}
+		logger.FromContext(ctx).Error("failed to create user", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	return &user, nil
+}
+
+// UpdateProfile changes the personal details of the user, see model.ProfileInput.
+func (s *Service) UpdateProfile(ctx context.Context, userID uuid.UUID, input *model.ProfileInput, expectedVersion *int) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.UpdateProfile")
+	defer span.End()
+
+	user, err := s.findForUpdate(ctx, userID, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName, lastName, position, email, department := user.FirstName, user.LastName, user.Position, user.Email, user.Department
+	if input.FirstName != nil {
+		firstName = *input.FirstName
+	}
+	if input.LastName != nil {
+		lastName = *input.LastName
+	}
+	if input.Position != nil {
+		position = *input.Position
+	}
+	if input.Email != nil {
+		email = *input.Email
+	}
+	if input.Department != nil {
+		department = *input.Department
+	}
+
+	changed := user.ChangeProfile(firstName, lastName, position, email, department)
+
+	if input.ManagerID != nil {
+		var managerID *uuid.UUID
+		if *input.ManagerID != uuid.Nil {
+			if err := s.checkManager(ctx, user.ID, *input.ManagerID); err != nil {
+				return nil, err
+			}
+			managerID = input.ManagerID
+		}
+		changed = user.ChangeManager(managerID) || changed
+	}
+
+	if !changed {
+		return user, nil
+	}
+
+	if err := s.update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ChangeRole moves the user to another role. The last active admin cannot be demoted,
+// and the pending forms of a former HR go to the other HRs. The sessions of the user
+// are revoked, as access tokens carry the role.
+func (s *Service) ChangeRole(ctx context.Context, userID uuid.UUID, role domain.Role, expectedVersion *int) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.ChangeRole")
+	defer span.End()
+
+	if !validRole(role) {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	var user *domain.User
+	var changed bool
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = s.findForUpdate(txCtx, userID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if role != domain.RoleAdmin {
+			if err := s.ensureOtherAdmin(txCtx, user); err != nil {
+				return err
+			}
+		}
+
+		previousRole := user.Role
+		if changed = user.ChangeRole(role); !changed {
+			return nil
+		}
+
+		if err := s.update(txCtx, user); err != nil {
+			return err
+		}
+
+		if previousRole == domain.RoleHR {
+			return s.reassignForms(txCtx, user.ID)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if changed {
+		if _, err := s.sessionRepo.RevokeAllByUserID(ctx, userID, time.Now()); err != nil {
+			logger.FromContext(ctx).Error("failed to revoke sessions", "target_user_id", userID, "error", err)
+			return nil, errs.ErrInternalServer
+		}
+	}
+
+	return user, nil
+}
+
+// DeleteUser removes an account together with its sessions and tokens. Users who
+// authored or reviewed forms must be deactivated instead, so the history stays intact,
+// and users under legal hold cannot be deleted at all.
+func (s *Service) DeleteUser(ctx context.Context, userID uuid.UUID, expectedVersion *int) error {
+	ctx, span := tracing.Start(ctx, "user.Service.DeleteUser")
+	defer span.End()
+
+	if err := s.legalHolds.Check(userID); err != nil {
+		return err
+	}
+
+	return s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		user, err := s.findForUpdate(txCtx, userID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ensureOtherAdmin(txCtx, user); err != nil {
+			return err
+		}
+
+		if user.Role == domain.RoleHR {
+			if err := s.reassignForms(txCtx, user.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.Delete(txCtx, user.ID); err != nil {
+			if errors.Is(err, errs.ErrUserHasForms) || errors.Is(err, errs.ErrUserNotFound) {
+				return err
+			}
+			logger.FromContext(ctx).Error("failed to delete user", "target_user_id", userID, "error", err)
+			return errs.ErrInternalServer
+		}
+		return nil
+	})
+}
+
+// findForUpdate returns the user, or errs.ErrPreconditionFailed when expectedVersion
+// is set and the user has changed since.
+func (s *Service) findForUpdate(ctx context.Context, userID uuid.UUID, expectedVersion *int) (*domain.User, error) {
+	user, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find user", "target_user_id", userID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	if expectedVersion != nil && *expectedVersion != user.Version {
+		return nil, errs.ErrPreconditionFailed
+	}
+
+	return user, nil
+}
+
+func (s *Service) update(ctx context.Context, user *domain.User) error {
+	if err := s.repo.Update(ctx, user); err != nil {
+		if errors.Is(err, errs.ErrPreconditionFailed) || errors.Is(err, errs.ErrUserAlreadyExists) {
+			return err
+		}
+		logger.FromContext(ctx).Error("failed to update user", "target_user_id", user.ID, "error", err)
+		return errs.ErrInternalServer
+	}
+	return nil
+}
+
+// checkManager fails with errs.ErrInvalidRequest unless the manager is another person.
+func (s *Service) checkManager(ctx context.Context, userID, managerID uuid.UUID) error {
+	if managerID == userID {
+		return errs.ErrInvalidRequest
+	}
+
+	manager, err := s.repo.FindByUserID(ctx, managerID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return errs.ErrInvalidRequest
+		}
+		logger.FromContext(ctx).Error("failed to find manager", "target_user_id", managerID, "error", err)
+		return errs.ErrInternalServer
+	}
+
+	if manager.IsServiceAccount {
+		return errs.ErrInvalidRequest
+	}
+	return nil
+}
+
+// ensureOtherAdmin fails with errs.ErrLastAdmin when the user is the only active
+// admin, before they are deactivated, demoted or deleted. Must run in a transaction.
+func (s *Service) ensureOtherAdmin(ctx context.Context, user *domain.User) error {
+	if !user.IsActiveAdmin() {
+		return nil
+	}
+
+	adminIDs, err := s.repo.LockActiveAdmins(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find active admins", "error", err)
+		return errs.ErrInternalServer
+	}
+
+	if !slices.ContainsFunc(adminIDs, func(id uuid.UUID) bool { return id != user.ID }) {
+		return errs.ErrLastAdmin
+	}
+	return nil
+}
+
+func (s *Service) reassignForms(ctx context.Context, executorID uuid.UUID) error {
+	count, err := s.forms.ReassignPendingForms(ctx, executorID)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		logger.FromContext(ctx).Info("pending forms reassigned", "target_user_id", executorID, "count", count)
+	}
+	return nil
+}
+
+func validRole(role domain.Role) bool {
+	switch role {
+	case domain.RoleEmployee, domain.RoleHR, domain.RoleAdmin:
+		return true
+	}
+	return false
+}
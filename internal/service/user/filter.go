@@ -0,0 +1,103 @@
+package user
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+)
+
+type SortField string
+
+const (
+	SortByName     SortField = "name"
+	SortByEmail    SortField = "email"
+	SortByPosition SortField = "position"
+	SortByRole     SortField = "role"
+)
+
+// Filter selects a page of users. Roles is set by the service to the roles
+// the requester may see, the other fields come from the request.
+type Filter struct {
+	Roles []domain.Role
+	// Search matches a substring of the name, email or position, case-insensitively
+	Search     string
+	Role       *domain.Role
+	IsActive   *bool
+	Department *string
+	// Email matches the whole email, case-insensitively
+	Email *string
+	// ExcludeServiceAccounts leaves out the accounts of integrations
+	ExcludeServiceAccounts bool
+
+	Sort SortField
+	Desc bool
+	// After continues the listing behind the last user of the previous page
+	After *Cursor
+	// Offset skips users from the start, for clients that page by position such as
+	// SCIM; it cannot be combined with After
+	Offset int
+	Limit  int
+	// WithTotal counts the users matching the filter into Page.Total
+	WithTotal bool
+}
+
+func (f *Filter) Validate() error {
+	switch f.Sort {
+	case SortByName, SortByEmail, SortByPosition, SortByRole:
+	default:
+		return errs.ErrInvalidRequest
+	}
+
+	if f.Role != nil && !validRole(*f.Role) {
+		return errs.ErrInvalidRequest
+	}
+
+	// A cursor only makes sense in the order it was issued for
+	if f.After != nil && (f.After.Sort != f.Sort || f.After.Desc != f.Desc) {
+		return errs.ErrInvalidRequest
+	}
+
+	if f.Offset < 0 || (f.Offset > 0 && f.After != nil) {
+		return errs.ErrInvalidRequest
+	}
+	return nil
+}
+
+// Cursor is the position of a user in a sorted listing: the value of the sort key,
+// with the id breaking ties.
+type Cursor struct {
+	Sort SortField `json:"s"`
+	Desc bool      `json:"d,omitempty"`
+	Key  string    `json:"k"`
+	ID   uuid.UUID `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token.
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func ParseCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == uuid.Nil {
+		return nil, errs.ErrInvalidRequest
+	}
+	return &c, nil
+}
+
+// Page is a slice of a listing; Next is nil on the last page.
+type Page struct {
+	Users []domain.User
+	Next  *Cursor
+	// Total is the number of users matching the filter, only counted with Filter.WithTotal
+	Total int
+}
@@ -0,0 +1,274 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/service/user/model"
+	"github.com/platonso/hrmate/internal/tracing"
+)
+
+// maxImportRows keeps an import within a single reasonable transaction
+const maxImportRows = 1000
+
+// ImportUsers creates the users of an import file in one transaction: either every
+// row is valid and all of them are created, or nothing is and the errors are reported.
+// Imported accounts are active; managers are matched by email among the rows and
+// the existing users.
+func (s *Service) ImportUsers(ctx context.Context, rows []model.ImportRow, opts model.ImportOptions) (*model.ImportResult, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.ImportUsers")
+	defer span.End()
+
+	if len(rows) == 0 || len(rows) > maxImportRows {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	// An undelivered temporary password leaves an account nobody can sign into
+	if opts.Invite && !s.inviter.Delivers() {
+		return nil, errs.ErrInvitationsUnavailable
+	}
+
+	users, rowErrors, err := s.prepareImport(ctx, rows)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to validate import", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	result := &model.ImportResult{Total: len(rows), Errors: rowErrors}
+	if len(rowErrors) > 0 || opts.DryRun {
+		return result, nil
+	}
+
+	// Hashing is slow, so it is done before the transaction is opened
+	passwords := make(map[uuid.UUID]string, len(users))
+	if opts.Invite {
+		for i := range users {
+			password, err := temporaryPassword()
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to generate password", "error", err)
+				return nil, errs.ErrInternalServer
+			}
+
+			users[i].HashedPassword, err = s.hasher.Hash(password)
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to hash password", "error", err)
+				return nil, errs.ErrInternalServer
+			}
+			passwords[users[i].ID] = password
+		}
+	}
+
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		for i := range users {
+			if err := s.repo.Create(txCtx, &users[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		// Someone registered one of the emails since the rows were validated
+		if errors.Is(err, errs.ErrUserAlreadyExists) {
+			return nil, errs.ErrUserAlreadyExists
+		}
+		logger.FromContext(ctx).Error("failed to import users", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	result.Created = len(users)
+
+	for i := range users {
+		if password, ok := passwords[users[i].ID]; ok {
+			s.inviter.UserInvited(ctx, &users[i], password)
+		}
+	}
+
+	return result, nil
+}
+
+// prepareImport validates the rows and builds their users. The returned error
+// is a failure to read the existing users, not an invalid row.
+func (s *Service) prepareImport(ctx context.Context, rows []model.ImportRow) ([]domain.User, []model.RowError, error) {
+	var rowErrors []model.RowError
+	invalid := func(row *model.ImportRow, column, rule, param string) {
+		rowErrors = append(rowErrors, model.RowError{Line: row.Line, Column: column, Rule: rule, Param: param})
+	}
+
+	lookup := make([]string, 0, len(rows)*2)
+	lines := make(map[string]int, len(rows))
+
+	for i := range rows {
+		row := &rows[i]
+		normalizeRow(row)
+
+		switch {
+		case row.Email == "":
+			invalid(row, "email", "required", "")
+		case !validEmail(row.Email):
+			invalid(row, "email", "email", "")
+		default:
+			if _, ok := lines[row.Email]; ok {
+				invalid(row, "email", "duplicate", "")
+			} else {
+				lines[row.Email] = row.Line
+			}
+			lookup = append(lookup, row.Email)
+		}
+
+		if row.FirstName == "" {
+			invalid(row, "first_name", "required", "")
+		}
+		if row.LastName == "" {
+			invalid(row, "last_name", "required", "")
+		}
+		if !validRole(domain.Role(row.Role)) {
+			invalid(row, "role", "oneof", "employee hr admin")
+		}
+
+		if row.ManagerEmail != "" {
+			switch {
+			case !validEmail(row.ManagerEmail):
+				invalid(row, "manager_email", "email", "")
+			case row.ManagerEmail == row.Email:
+				invalid(row, "manager_email", "self", "")
+			default:
+				lookup = append(lookup, row.ManagerEmail)
+			}
+		}
+	}
+
+	existing, err := s.repo.FindByEmails(ctx, lookup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existingByEmail := make(map[string]*domain.User, len(existing))
+	for i := range existing {
+		existingByEmail[existing[i].Email] = &existing[i]
+	}
+
+	users := make([]domain.User, len(rows))
+	idsByEmail := make(map[string]uuid.UUID, len(rows))
+	for i, row := range rows {
+		users[i] = domain.NewUser(domain.Role(row.Role), row.FirstName, row.LastName, row.Position, row.Email, "")
+		users[i].Department = row.Department
+		users[i].Activate()
+
+		if _, ok := existingByEmail[row.Email]; ok && row.Email != "" {
+			invalid(&rows[i], "email", "taken", "")
+		}
+		if lines[row.Email] == row.Line {
+			idsByEmail[row.Email] = users[i].ID
+		}
+	}
+
+	for i, row := range rows {
+		if row.ManagerEmail == "" || row.ManagerEmail == row.Email {
+			continue
+		}
+
+		if id, ok := idsByEmail[row.ManagerEmail]; ok {
+			users[i].ManagerID = &id
+			continue
+		}
+		if manager, ok := existingByEmail[row.ManagerEmail]; ok && !manager.IsServiceAccount {
+			users[i].ManagerID = &manager.ID
+			continue
+		}
+		if validEmail(row.ManagerEmail) {
+			invalid(&rows[i], "manager_email", "unknown_manager", "")
+		}
+	}
+
+	// Report the errors of a line together, in the order of the file
+	slices.SortStableFunc(rowErrors, func(a, b model.RowError) int {
+		return a.Line - b.Line
+	})
+
+	return users, rowErrors, nil
+}
+
+// ExportUsers returns every person in the system, without service accounts,
+// sorted by name in the columns of an import.
+func (s *Service) ExportUsers(ctx context.Context) ([]model.ExportRow, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.ExportUsers")
+	defer span.End()
+
+	users, err := s.repo.FindByRole(ctx, domain.RoleAdmin, domain.RoleHR, domain.RoleEmployee)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find users", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	emails := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		emails[u.ID] = u.Email
+	}
+
+	users = slices.DeleteFunc(users, func(u domain.User) bool {
+		return u.IsServiceAccount
+	})
+	slices.SortFunc(users, func(a, b domain.User) int {
+		if c := strings.Compare(strings.ToLower(a.LastName), strings.ToLower(b.LastName)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(strings.ToLower(a.FirstName), strings.ToLower(b.FirstName)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Email, b.Email)
+	})
+
+	rows := make([]model.ExportRow, len(users))
+	for i, u := range users {
+		rows[i] = model.ExportRow{
+			Email:      u.Email,
+			FirstName:  u.FirstName,
+			LastName:   u.LastName,
+			Role:       u.Role,
+			Position:   u.Position,
+			Department: u.Department,
+		}
+		if u.ManagerID != nil {
+			rows[i].ManagerEmail = emails[*u.ManagerID]
+		}
+	}
+
+	return rows, nil
+}
+
+func normalizeRow(row *model.ImportRow) {
+	row.Email = strings.TrimSpace(row.Email)
+	row.FirstName = strings.TrimSpace(row.FirstName)
+	row.LastName = strings.TrimSpace(row.LastName)
+	row.Role = strings.ToLower(strings.TrimSpace(row.Role))
+	row.Position = strings.TrimSpace(row.Position)
+	row.Department = strings.TrimSpace(row.Department)
+	row.ManagerEmail = strings.TrimSpace(row.ManagerEmail)
+
+	if row.Role == "" {
+		row.Role = string(domain.RoleEmployee)
+	}
+}
+
+// validEmail accepts a bare address, as the API does, and not a "Name <address>" form.
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// temporaryPassword is long and random enough to pass any reasonable password policy.
+func temporaryPassword() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/platonso/hrmate/internal/domain"
+)
+
+// CreateUserInput is an account created by an administrator or provisioned by an
+// identity provider. Without a password the user signs in through single sign-on.
+type CreateUserInput struct {
+	Role       domain.Role
+	FirstName  string
+	LastName   string
+	Position   string
+	Email      string
+	Password   string
+	Department string
+	ManagerID  *uuid.UUID
+	IsActive   bool
+}
+
+// ProfileInput changes the fields that are set and keeps the others.
+// An empty department removes the user from their department, uuid.Nil their manager.
+type ProfileInput struct {
+	FirstName  *string
+	LastName   *string
+	Position   *string
+	Email      *string
+	Department *string
+	ManagerID  *uuid.UUID
+}
+
+// ImportRow is a user read from an import file. Line is the line of the file,
+// counting the header, so that errors can be found in the spreadsheet.
+type ImportRow struct {
+	Line         int
+	Email        string
+	FirstName    string
+	LastName     string
+	Role         string
+	Position     string
+	Department   string
+	ManagerEmail string
+}
+
+type ImportOptions struct {
+	// DryRun only validates the rows
+	DryRun bool
+	// Invite emails every created user a temporary password, which they replace
+	// with PUT /api/v1/me/password. It requires a mail server. Without it the
+	// accounts have no password and sign in through single sign-on.
+	Invite bool
+}
+
+// RowError is an invalid cell of an import file. Rule and Param follow the
+// rules of request validation, e.g. "required" or "oneof" with the allowed values.
+type RowError struct {
+	Line   int
+	Column string
+	Rule   string
+	Param  string
+}
+
+// ImportResult reports the rows of an import; nothing is created when there are errors.
+type ImportResult struct {
+	Total   int
+	Created int
+	Errors  []RowError
+}
+
+// ExportRow has the columns of ImportRow, so that an export can be imported again.
+type ExportRow struct {
+	Email        string
+	FirstName    string
+	LastName     string
+	Role         domain.Role
+	Position     string
+	Department   string
+	ManagerEmail string
+}
@@ -3,60 +3,207 @@ package user
 import (
 	"context"
 	"errors"
-	"log"
+	"strings"
+	"time"
 
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/google/uuid"
 	"github.com/platonso/hrmate/internal/domain"
 	errs "github.com/platonso/hrmate/internal/errors"
+	"github.com/platonso/hrmate/internal/i18n"
+	"github.com/platonso/hrmate/internal/logger"
+	"github.com/platonso/hrmate/internal/tracing"
 )
 
 type Repository interface {
+	Create(ctx context.Context, user *domain.User) error
 	Update(ctx context.Context, user *domain.User) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindByEmails(ctx context.Context, emails []string) ([]domain.User, error)
+	LockActiveAdmins(ctx context.Context) ([]uuid.UUID, error)
 	FindByRole(ctx context.Context, roles ...domain.Role) ([]domain.User, error)
 	FindByUserID(ctx context.Context, userId uuid.UUID) (*domain.User, error)
-	IsActive(ctx context.Context, userID uuid.UUID) (bool, error)
+	FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.User, error)
+	FindPage(ctx context.Context, filter *Filter) (*Page, error)
 }
+
+type SessionRepository interface {
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int64, error)
+}
+
+// FormReassigner moves the pending forms of an HR who can no longer review them.
+type FormReassigner interface {
+	ReassignPendingForms(ctx context.Context, executorID uuid.UUID) (int, error)
+}
+
+// Inviter tells imported users how to sign in.
+type Inviter interface {
+	UserInvited(ctx context.Context, user *domain.User, password string)
+	// Delivers reports whether invitations actually reach the users
+	Delivers() bool
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+}
+
+type PasswordPolicy interface {
+	Validate(password, email string) error
+}
+
 type Service struct {
-	repo Repository
+	txMgr       trm.Manager
+	repo        Repository
+	sessionRepo SessionRepository
+	forms       FormReassigner
+	inviter     Inviter
+	hasher      PasswordHasher
+	policy      PasswordPolicy
+	legalHolds  domain.LegalHolds
 }
 
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+func NewService(
+	txMgr trm.Manager,
+	repo Repository,
+	sessionRepo SessionRepository,
+	forms FormReassigner,
+	inviter Inviter,
+	hasher PasswordHasher,
+	policy PasswordPolicy,
+	legalHolds domain.LegalHolds,
+) *Service {
+	return &Service{
+		txMgr:       txMgr,
+		repo:        repo,
+		sessionRepo: sessionRepo,
+		forms:       forms,
+		inviter:     inviter,
+		hasher:      hasher,
+		policy:      policy,
+		legalHolds:  legalHolds,
+	}
 }
 
 func (s *Service) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.GetUserByID")
+	defer span.End()
+
 	user, err := s.repo.FindByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
 			return nil, errs.ErrUserNotFound
 		}
-		log.Printf("failed to get user %s: %v", userID, err)
+		logger.FromContext(ctx).Error("failed to get user", "target_user_id", userID, "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
 	return user, nil
 }
 
-func (s *Service) ChangeActiveStatus(ctx context.Context, userID uuid.UUID, isActive bool) (*domain.User, error) {
+// GetUser returns the user if the requester may see them: admins see everyone,
+// HR sees employees and everyone sees themselves.
+func (s *Service) GetUser(ctx context.Context, userID, requesterID uuid.UUID, requesterRole domain.Role) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.GetUser")
+	defer span.End()
+
 	user, err := s.repo.FindByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
 			return nil, errs.ErrUserNotFound
 		}
-		log.Printf("failed to find user %s: %v", userID, err)
+		logger.FromContext(ctx).Error("failed to get user", "target_user_id", userID, "error", err)
 		return nil, errs.ErrInternalServer
 	}
 
-	var changed bool
-	if isActive {
-		changed = user.Activate()
-	} else {
-		changed = user.Deactivate()
+	if !canView(user, requesterID, requesterRole) {
+		// Hide the existence of users the requester may not see
+		return nil, errs.ErrUserNotFound
 	}
 
-	if changed {
-		if err := s.repo.Update(ctx, user); err != nil {
-			log.Printf("failed to update user %s: %v", userID, err)
+	return user, nil
+}
+
+// GetUsersByIDs returns the users the requester may see, as GetUser does, in one query.
+// Unknown and hidden users are left out.
+func (s *Service) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID, requesterID uuid.UUID, requesterRole domain.Role) ([]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.GetUsersByIDs")
+	defer span.End()
+
+	users, err := s.repo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find users by ids", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	visible := make([]domain.User, 0, len(users))
+	for i := range users {
+		if canView(&users[i], requesterID, requesterRole) {
+			visible = append(visible, users[i])
+		}
+	}
+
+	return visible, nil
+}
+
+// canView reports whether the requester may see the user: admins see everyone,
+// HR sees employees and everyone sees themselves.
+func canView(user *domain.User, requesterID uuid.UUID, requesterRole domain.Role) bool {
+	switch {
+	case user.ID == requesterID, requesterRole == domain.RoleAdmin:
+		return true
+	case requesterRole == domain.RoleHR && user.Role == domain.RoleEmployee:
+		return true
+	}
+	return false
+}
+
+// ChangeActiveStatus activates or deactivates the user. When expectedVersion is set,
+// the change is applied only to that version of the user. The last active admin cannot
+// be deactivated, and the pending forms of a deactivated HR go to the other HRs.
+func (s *Service) ChangeActiveStatus(ctx context.Context, userID uuid.UUID, isActive bool, expectedVersion *int) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.ChangeActiveStatus")
+	defer span.End()
+
+	var user *domain.User
+	if err := s.txMgr.Do(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = s.findForUpdate(txCtx, userID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if !isActive {
+			if err := s.ensureOtherAdmin(txCtx, user); err != nil {
+				return err
+			}
+		}
+
+		var changed bool
+		if isActive {
+			changed = user.Activate()
+		} else {
+			changed = user.Deactivate()
+		}
+		if !changed {
+			return nil
+		}
+
+		if err := s.update(txCtx, user); err != nil {
+			return err
+		}
+
+		if !user.IsActive && user.Role == domain.RoleHR {
+			return s.reassignForms(txCtx, user.ID)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		if _, err := s.sessionRepo.RevokeAllByUserID(ctx, userID, time.Now()); err != nil {
+			logger.FromContext(ctx).Error("failed to revoke sessions", "target_user_id", userID, "error", err)
 			return nil, errs.ErrInternalServer
 		}
 	}
@@ -64,39 +211,82 @@ func (s *Service) ChangeActiveStatus(ctx context.Context, userID uuid.UUID, isAc
 	return user, nil
 }
 
-func (s *Service) GetUsersByRole(ctx context.Context, requesterRole domain.Role) ([]domain.User, error) {
-	var rolesToQuery []domain.Role
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// SearchUsers returns a page of the users the requester may list: HR sees employees,
+// admins see everyone. The listing is sorted by name unless the filter says otherwise.
+func (s *Service) SearchUsers(ctx context.Context, filter *Filter, requesterRole domain.Role) (*Page, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.SearchUsers")
+	defer span.End()
 
 	switch requesterRole {
 	case domain.RoleAdmin:
-		rolesToQuery = []domain.Role{domain.RoleHR, domain.RoleEmployee}
+		filter.Roles = []domain.Role{domain.RoleAdmin, domain.RoleHR, domain.RoleEmployee}
 	case domain.RoleHR:
-		rolesToQuery = []domain.Role{domain.RoleEmployee}
+		filter.Roles = []domain.Role{domain.RoleEmployee}
 	default:
 		return nil, errs.ErrForbidden
 	}
 
-	users, err := s.repo.FindByRole(ctx, rolesToQuery...)
-	if err != nil {
-		log.Printf("failed to find users by roles %v: %v", rolesToQuery, err)
-		return nil, errs.ErrInternalServer
+	if filter.Sort == "" {
+		filter.Sort = SortByName
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
 	}
 
-	if users == nil {
-		users = []domain.User{}
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultPageSize
+	case filter.Limit > maxPageSize:
+		filter.Limit = maxPageSize
 	}
+	filter.Search = strings.TrimSpace(filter.Search)
 
-	return users, nil
+	page, err := s.repo.FindPage(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find users", "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	return page, nil
 }
 
-func (s *Service) IsActive(ctx context.Context, userID uuid.UUID) (bool, error) {
-	active, err := s.repo.IsActive(ctx, userID)
+// ChangeLocale stores the preferred language of the user. An empty locale
+// resets the preference, so the Accept-Language header is used again.
+func (s *Service) ChangeLocale(ctx context.Context, userID uuid.UUID, locale string) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Service.ChangeLocale")
+	defer span.End()
+
+	if locale != "" {
+		supported, ok := i18n.Supported(locale)
+		if !ok {
+			return nil, errs.ErrInvalidRequest
+		}
+		locale = supported
+	}
+
+	user, err := s.repo.FindByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
-			return false, errs.ErrUserNotFound
+			return nil, errs.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("failed to find user", "target_user_id", userID, "error", err)
+		return nil, errs.ErrInternalServer
+	}
+
+	if user.ChangeLocale(locale) {
+		if err := s.repo.Update(ctx, user); err != nil {
+			if errors.Is(err, errs.ErrPreconditionFailed) {
+				return nil, errs.ErrPreconditionFailed
+			}
+			logger.FromContext(ctx).Error("failed to update locale", "target_user_id", userID, "error", err)
+			return nil, errs.ErrInternalServer
 		}
-		log.Printf("failed to check active status for user %s: %v", userID, err)
-		return false, errs.ErrInternalServer
 	}
-	return active, nil
+
+	return user, nil
 }
@@ -0,0 +1,187 @@
+// Package spreadsheet reads and writes tables as CSV or XLSX files.
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+const (
+	ContentTypeCSV  = "text/csv; charset=utf-8"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+var ErrUnknownFormat = errors.New("unknown spreadsheet format")
+
+// utf8BOM is written by Excel at the start of CSV files saved as UTF-8
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParseFormat accepts the name of a format as given in a request.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case CSV:
+		return CSV, nil
+	case XLSX:
+		return XLSX, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// DetectFormat tells the format of an uploaded file by its name, or by its content
+// when the name has no known extension: XLSX files are ZIP archives.
+func DetectFormat(filename string, head []byte) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xlsx":
+		return XLSX
+	case ".csv":
+		return CSV
+	}
+
+	if bytes.HasPrefix(head, []byte("PK\x03\x04")) {
+		return XLSX
+	}
+	return CSV
+}
+
+func (f Format) ContentType() string {
+	if f == XLSX {
+		return ContentTypeXLSX
+	}
+	return ContentTypeCSV
+}
+
+// Read returns the rows of the file, of the first sheet for XLSX. Trailing empty
+// rows are dropped and short rows are not padded.
+func Read(r io.Reader, format Format) ([][]string, error) {
+	var rows [][]string
+
+	switch format {
+	case CSV:
+		var err error
+		if rows, err = readCSV(r); err != nil {
+			return nil, err
+		}
+
+	case XLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("open xlsx: %w", err)
+		}
+		defer file.Close()
+
+		if rows, err = file.GetRows(file.GetSheetName(0)); err != nil {
+			return nil, fmt.Errorf("read xlsx: %w", err)
+		}
+
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	for len(rows) > 0 && isEmpty(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+// readCSV accepts the comma and the semicolon, which Excel uses as the separator
+// in locales with a decimal comma.
+func readCSV(r io.Reader) ([][]string, error) {
+	br := bufio.NewReader(r)
+	if head, _ := br.Peek(len(utf8BOM)); bytes.Equal(head, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	if firstLine, _ := br.Peek(br.Buffered()); isSemicolonSeparated(firstLine) {
+		reader.Comma = ';'
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	return rows, nil
+}
+
+func isSemicolonSeparated(data []byte) bool {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	return bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(","))
+}
+
+// Write writes the rows to a CSV file or to the first sheet of an XLSX file.
+func Write(w io.Writer, format Format, rows [][]string) error {
+	switch format {
+	case CSV:
+		writer := csv.NewWriter(w)
+		for _, row := range rows {
+			if err := writer.Write(escapeFormulas(row)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case XLSX:
+		file := excelize.NewFile()
+		defer file.Close()
+
+		sheet := file.GetSheetName(0)
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+
+			values := make([]any, len(row))
+			for j, v := range row {
+				values[j] = v
+			}
+			if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+				return err
+			}
+		}
+		return file.Write(w)
+
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// escapeFormulas keeps spreadsheet applications from evaluating values that
+// look like formulas. XLSX cells are written as strings and need no escaping.
+func escapeFormulas(row []string) []string {
+	escaped := make([]string, len(row))
+	for i, v := range row {
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			v = "'" + v
+		}
+		escaped[i] = v
+	}
+	return escaped
+}
+
+func isEmpty(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TxManager wraps a transaction manager so every transaction gets its own span
+// and the queries it runs are grouped under it.
+type TxManager struct {
+	next trm.Manager
+}
+
+func NewTxManager(next trm.Manager) *TxManager {
+	return &TxManager{next: next}
+}
+
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, span := Start(ctx, "db.transaction")
+	defer span.End()
+
+	err := m.next.Do(ctx, fn)
+	recordError(span, err)
+	return err
+}
+
+func (m *TxManager) DoWithSettings(ctx context.Context, settings trm.Settings, fn func(ctx context.Context) error) error {
+	ctx, span := Start(ctx, "db.transaction")
+	defer span.End()
+
+	err := m.next.DoWithSettings(ctx, settings, fn)
+	recordError(span, err)
+	return err
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_identities (
+                                     issuer TEXT NOT NULL,
+                                     subject TEXT NOT NULL,
+                                     user_id UUID NOT NULL,
+                                     email TEXT NOT NULL,
+                                     created_at TIMESTAMPTZ NOT NULL,
+                                     PRIMARY KEY (issuer, subject),
+                                     CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS oidc_states (
+                                     state TEXT PRIMARY KEY,
+                                     code_verifier TEXT NOT NULL,
+                                     nonce TEXT NOT NULL,
+                                     expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+CREATE INDEX IF NOT EXISTS idx_oidc_states_expires_at ON oidc_states(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS oidc_states;
+DROP TABLE IF EXISTS user_identities;
+-- +goose StatementEnd
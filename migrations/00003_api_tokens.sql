@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_service_account BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+                                     id UUID PRIMARY KEY,
+                                     user_id UUID NOT NULL,
+                                     name TEXT NOT NULL,
+                                     prefix TEXT NOT NULL,
+                                     token_hash TEXT UNIQUE NOT NULL,
+                                     scopes TEXT[] NOT NULL,
+                                     created_at TIMESTAMPTZ NOT NULL,
+                                     expires_at TIMESTAMPTZ NOT NULL,
+                                     last_used_at TIMESTAMPTZ,
+                                     revoked_at TIMESTAMPTZ,
+                                     CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS is_service_account;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sessions (
+                                     id UUID PRIMARY KEY,
+                                     user_id UUID NOT NULL,
+                                     device TEXT NOT NULL,
+                                     ip_address TEXT NOT NULL,
+                                     user_agent TEXT NOT NULL,
+                                     created_at TIMESTAMPTZ NOT NULL,
+                                     last_seen_at TIMESTAMPTZ NOT NULL,
+                                     expires_at TIMESTAMPTZ NOT NULL,
+                                     revoked_at TIMESTAMPTZ,
+                                     CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sessions;
+-- +goose StatementEnd
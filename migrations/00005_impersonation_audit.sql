@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS impersonator_id UUID;
+ALTER TABLE sessions ADD CONSTRAINT fk_sessions_impersonator FOREIGN KEY (impersonator_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+                                     id UUID PRIMARY KEY,
+                                     actor_id UUID NOT NULL,
+                                     subject_id UUID,
+                                     resource_id UUID,
+                                     method TEXT NOT NULL,
+                                     route TEXT NOT NULL,
+                                     path TEXT NOT NULL,
+                                     status_code INT NOT NULL,
+                                     ip_address TEXT NOT NULL,
+                                     created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_subject_id ON audit_log(subject_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_resource_id ON audit_log(resource_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+ALTER TABLE sessions DROP COLUMN IF EXISTS impersonator_id;
+-- +goose StatementEnd
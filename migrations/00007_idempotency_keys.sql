@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+                                     user_id UUID NOT NULL,
+                                     idempotency_key TEXT NOT NULL,
+                                     method TEXT NOT NULL,
+                                     path TEXT NOT NULL,
+                                     fingerprint TEXT NOT NULL,
+                                     status_code INT,
+                                     content_type TEXT,
+                                     response_body BYTEA,
+                                     created_at TIMESTAMPTZ NOT NULL,
+                                     completed_at TIMESTAMPTZ,
+                                     expires_at TIMESTAMPTZ NOT NULL,
+                                     PRIMARY KEY (user_id, idempotency_key),
+                                     CONSTRAINT fk_idempotency_keys_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
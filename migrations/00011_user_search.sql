@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Trigram indexes serve the substring search of the user lists
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING GIN ((first_name || ' ' || last_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING GIN (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_position_trgm ON users USING GIN (position gin_trgm_ops);
+
+-- Keyset pagination walks these in order of the sort key and id
+CREATE INDEX IF NOT EXISTS idx_users_name_sort ON users (lower(last_name || ' ' || first_name), id);
+CREATE INDEX IF NOT EXISTS idx_users_position_sort ON users (lower(position), id);
+CREATE INDEX IF NOT EXISTS idx_users_role ON users (user_role, id);
+CREATE INDEX IF NOT EXISTS idx_users_department ON users (department);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_department;
+DROP INDEX IF EXISTS idx_users_role;
+DROP INDEX IF EXISTS idx_users_position_sort;
+DROP INDEX IF EXISTS idx_users_name_sort;
+DROP INDEX IF EXISTS idx_users_position_trgm;
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deferred, so that an import can reference managers created later in the same transaction
+ALTER TABLE users ADD COLUMN IF NOT EXISTS manager_id UUID
+    REFERENCES users (id) ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED;
+
+CREATE INDEX IF NOT EXISTS idx_users_manager ON users (manager_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_manager;
+ALTER TABLE users DROP COLUMN IF EXISTS manager_id;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Departments give the department names of users a stable identity, e.g. for SCIM groups.
+-- Users still refer to their department by name.
+CREATE TABLE IF NOT EXISTS departments (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO departments (name)
+SELECT DISTINCT department FROM users WHERE department IS NOT NULL AND department <> ''
+ON CONFLICT (name) DO NOTHING;
+
+-- Every department a user is assigned to exists, however the user was written
+CREATE OR REPLACE FUNCTION ensure_user_department() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.department IS NOT NULL AND NEW.department <> '' THEN
+        INSERT INTO departments (name) VALUES (NEW.department) ON CONFLICT (name) DO NOTHING;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_ensure_department
+    BEFORE INSERT OR UPDATE OF department ON users
+    FOR EACH ROW EXECUTE FUNCTION ensure_user_department();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS users_ensure_department ON users;
+DROP FUNCTION IF EXISTS ensure_user_department();
+DROP TABLE IF EXISTS departments;
+-- +goose StatementEnd